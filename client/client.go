@@ -2,8 +2,11 @@ package client
 
 import (
 	"fmt"
+	"net/http"
+	"sync"
 
 	"otc-cli/config"
+	"otc-cli/errs"
 
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
@@ -48,12 +51,64 @@ func GetAuthOpts(config *config.CommonConfig) (golangsdk.AuthOptionsProvider, er
 	return opts, nil
 }
 
+// authentication is a pending or completed authentication with one set of
+// options. done is closed once client or err is set.
+type authentication struct {
+	done   chan struct{}
+	client *golangsdk.ProviderClient
+	err    error
+}
+
+var (
+	authentications   = map[string]*authentication{}
+	authenticationsMu sync.Mutex
+)
+
+// GetAuthenticatedClient returns a provider client for the options. Clients
+// are cached for the lifetime of the process, so repeated calls with the same
+// options (e.g. when polling) authenticate only once. Concurrent calls with
+// the same options wait for a single authentication, while calls with other
+// options authenticate in parallel. Failed authentications are not cached.
+// Expired tokens are renewed by the SDK where the options allow
+// re-authentication.
 func GetAuthenticatedClient(opts golangsdk.AuthOptionsProvider) (*golangsdk.ProviderClient, error) {
-	client, err := openstack.AuthenticatedClient(opts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate client: %s", err)
+	key := fmt.Sprintf("%T%+v", opts, opts)
+
+	authenticationsMu.Lock()
+	auth, ok := authentications[key]
+	if ok {
+		authenticationsMu.Unlock()
+		<-auth.done
+		return auth.client, auth.err
+	}
+	auth = &authentication{done: make(chan struct{})}
+	authentications[key] = auth
+	authenticationsMu.Unlock()
+
+	auth.client, auth.err = openstack.AuthenticatedClient(opts)
+	if auth.err != nil {
+		auth.err = authError(opts, auth.err)
+
+		authenticationsMu.Lock()
+		delete(authentications, key)
+		authenticationsMu.Unlock()
+	}
+	close(auth.done)
+
+	return auth.client, auth.err
+}
+
+// authError classifies a failed authentication. A rejected security token
+// means the temporary credentials of "otc login" expired.
+func authError(opts golangsdk.AuthOptionsProvider, err error) error {
+	if !errs.IsStatus(err, http.StatusUnauthorized) && !errs.IsStatus(err, http.StatusForbidden) {
+		return err
+	}
+
+	if akskOpts, ok := opts.(golangsdk.AKSKAuthOptions); ok && akskOpts.SecurityToken != "" {
+		return &errs.ExpiredCredentialsError{Err: err}
 	}
-	return client, nil
+	return &errs.UnauthorizedError{Err: err}
 }
 
 func setIfEmpty(value *string, newValue string) {
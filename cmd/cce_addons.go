@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/spf13/cobra"
+)
+
+var cceAddonsCmd = &cobra.Command{
+	Use:   "addons",
+	Short: "Inspect add-ons of CCE clusters",
+}
+
+var cceAddonsListCmd = &cobra.Command{
+	Use:   "list <cluster-name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "List add-ons available for a cluster and their installed versions",
+	Long: `List the add-ons available for a cluster and the versions installed in it.
+
+The latest version is the newest one supporting the cluster's Kubernetes
+version; target versions are those the installed add-on can be upgraded to.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addonList, err := cce.ListAddons(args[0], commonConfig)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, addonList, addonsTableView())
+	},
+}
+
+func init() {
+	cceCmd.AddCommand(cceAddonsCmd)
+	cceAddonsCmd.AddCommand(cceAddonsListCmd)
+	initFlagFormat(cceAddonsListCmd)
+}
+
+func addonsTableView() formats.View[cce.Addon] {
+	return formats.View[cce.Addon]{
+		Columns: []formats.Column[cce.Addon]{
+			formats.Col("Name", func(a cce.Addon) string {
+				return a.Name
+			}),
+			formats.Col("Type", func(a cce.Addon) string {
+				return a.Type
+			}),
+			formats.Col("Required", func(a cce.Addon) bool {
+				return a.Required
+			}, formats.BoolYesNo[cce.Addon]()),
+			formats.Col("Installed", func(a cce.Addon) string {
+				return a.InstalledVersion
+			}),
+			formats.Col("Status", func(a cce.Addon) string {
+				return a.Status
+			}),
+			formats.Col("Latest", func(a cce.Addon) string {
+				return a.LatestVersion
+			}),
+			formats.Col("Target Versions", func(a cce.Addon) []string {
+				return a.TargetVersions
+			}, formats.Lines[cce.Addon]()),
+		},
+	}
+}
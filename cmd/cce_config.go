@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"otc-cli/errs"
+	"otc-cli/formats"
 	"otc-cli/services/cce"
 
 	"github.com/spf13/cobra"
@@ -10,23 +12,126 @@ import (
 // configCmd represents the config command
 var configCmd = &cobra.Command{
 	Use:   "config <cluster-name>",
-	Args:  cobra.ExactArgs(1),
-	Short: "Print a kubeconfig for a CCE cluster",
-	Run: func(cmd *cobra.Command, args []string) {
-		cceConfigArgs.ClusterName = args[0]
+	Short: "Add a CCE cluster to the kubeconfig",
+	Long: `Add a CCE cluster to the kubeconfig.
+
+The cluster, user and context are named otc-<cloud>-<cluster> and merged into
+the first file of $KUBECONFIG, or ~/.kube/config. Entries with the same name
+are replaced, all other entries are kept. The current context is only changed
+with --set-current or when none is set yet.
+
+With --exec the kubeconfig does not embed a client certificate but runs
+"otc cce credential" to obtain short-lived certificates with the current
+session from "otc login".
 
-		if err := cce.Config(cceConfigArgs); err != nil {
-			fmt.Printf("Error printing kubeconfig for CCE cluster '%s': %s\n", args[0], err)
+--endpoint selects whether the context uses the cluster's internal address
+or its external one on the EIP, and --expiry limits the validity of the
+embedded certificate (e.g. 7d), which otherwise does not expire.
+
+With --all the clusters of all clouds in clouds.yaml, or those given with
+--clouds, are fetched concurrently and merged. --prune additionally removes
+the contexts of these clouds whose cluster no longer exists. Contexts record
+their cloud and cluster in an extension, contexts without it are kept.
+--format selects the output of the results of --all.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cceConfigAll {
+			return cobra.NoArgs(cmd, args)
 		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if !cceConfigAll && (len(cceSyncArgs.Clouds) > 0 || cceSyncArgs.Prune) {
+			return usageErrorf("--clouds and --prune require --all")
+		}
+		if cceConfigAll && (cceConfigArgs.Stdout || cceConfigArgs.SetCurrentContext) {
+			return usageErrorf("--stdout and --set-current cannot be combined with --all")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cceConfigAll {
+			return syncKubeconfig()
+		}
+		cceConfigArgs.ClusterName = args[0]
+		return cce.Config(cceConfigArgs)
 	},
 }
 
 var cceConfigArgs = cce.ConfigArgs{
-	OutputPath:   "",
+	OutputPath:        "",
+	Stdout:            false,
+	SetCurrentContext: false,
+	CommonConfig:      commonConfig,
+	KubeconfigOptions: cce.KubeconfigOptions{
+		Exec:     false,
+		Endpoint: "",
+		Expiry:   0,
+	},
+}
+
+var cceSyncArgs = cce.SyncArgs{
+	Clouds:       nil,
+	Prune:        false,
 	CommonConfig: commonConfig,
 }
 
+var cceConfigAll bool
+
 func init() {
 	cceCmd.AddCommand(configCmd)
-	configCmd.Flags().StringVar(&cceConfigArgs.OutputPath, "output", cceConfigArgs.OutputPath, "Path to write the kubeconfig file. If not specified, prints to stdout.")
+	configCmd.Flags().StringVar(&cceConfigArgs.OutputPath, "output", cceConfigArgs.OutputPath, "Kubeconfig file to merge into (default $KUBECONFIG or ~/.kube/config)")
+	configCmd.Flags().BoolVar(&cceConfigArgs.Stdout, "stdout", cceConfigArgs.Stdout, "Print the kubeconfig of the cluster instead of merging it")
+	configCmd.Flags().BoolVar(&cceConfigArgs.SetCurrentContext, "set-current", cceConfigArgs.SetCurrentContext, "Make the cluster the current context")
+	configCmd.Flags().BoolVar(&cceConfigArgs.Exec, "exec", cceConfigArgs.Exec, "Authenticate with short-lived credentials from \"otc cce credential\" instead of an embedded certificate")
+	configCmd.Flags().StringVar(&cceConfigArgs.Endpoint, "endpoint", cceConfigArgs.Endpoint, "API server endpoint to use: "+cce.EndpointInternal+" or "+cce.EndpointExternal+" (default as returned by CCE)")
+	dayDurationVar(configCmd.Flags(), &cceConfigArgs.Expiry, "expiry", "Validity of the client certificate, rounded up to whole days, e.g. 7d (0 never expires)")
+	configCmd.Flags().BoolVar(&cceConfigAll, "all", cceConfigAll, "Merge the kubeconfig of every cluster in the selected clouds")
+	configCmd.Flags().StringSliceVar(&cceSyncArgs.Clouds, "clouds", cceSyncArgs.Clouds, "Clouds from clouds.yaml to sync with --all (default all)")
+	configCmd.Flags().BoolVar(&cceSyncArgs.Prune, "prune", cceSyncArgs.Prune, "Remove contexts of clusters that no longer exist in the synced clouds")
+	initFlagFormat(configCmd)
+}
+
+func syncKubeconfig() error {
+	cceSyncArgs.KubeconfigOptions = cceConfigArgs.KubeconfigOptions
+	cceSyncArgs.OutputPath = cceConfigArgs.OutputPath
+
+	results, path, err := cce.Sync(cceSyncArgs)
+	if len(results) > 0 {
+		if err := formats.PrintFormatted(format, results, syncResultsTableView()); err != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if format == "table" {
+		fmt.Printf("Kubeconfig written to %s\n", path)
+	}
+
+	if failed := errs.FailedCount(results); failed > 0 {
+		return fmt.Errorf("%d of %d clusters or clouds failed", failed, len(results))
+	}
+	return nil
+}
+
+func syncResultsTableView() formats.View[cce.SyncResult] {
+	return formats.View[cce.SyncResult]{
+		Columns: []formats.Column[cce.SyncResult]{
+			formats.Col("Cloud", func(r cce.SyncResult) string {
+				return r.Cloud
+			}),
+			formats.Col("Cluster", func(r cce.SyncResult) string {
+				return r.Cluster
+			}),
+			formats.Col("Context", func(r cce.SyncResult) string {
+				return r.Context
+			}),
+			formats.Col("Action", func(r cce.SyncResult) string {
+				return r.Action
+			}),
+			formats.Col("Error", func(r cce.SyncResult) string {
+				return r.Error
+			}),
+		},
+	}
 }
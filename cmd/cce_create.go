@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/spf13/cobra"
+)
+
+var cceCreateCmd = &cobra.Command{
+	Use:   "create [name] -f <spec.yaml>",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Create a CCE cluster",
+	Long: `Create a CCE cluster from a YAML spec file.
+
+The spec file describes the cluster like the CCE API does, with metadata and
+spec sections; kind and apiVersion may be omitted. The VPC and subnet are
+given by ID. A name on the command line overrides metadata.name, so one spec
+can be reused for several clusters:
+
+  metadata:
+    name: dev-cluster
+  spec:
+    flavor: cce.s1.small
+    version: v1.29
+    hostNetwork:
+      vpc: 3b9740a0-b44d-48f0-84ee-42eb166e54f7
+      subnet: 0c4a2e2b-1b0a-4f34-a4b0-0b1b2e1c5d55
+    containerNetwork:
+      mode: vpc-router`,
+	Example: `  otc cce create -f cluster.yaml --wait
+  otc cce create dev-cluster -f cluster.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			cceCreateArgs.Name = args[0]
+		}
+
+		cluster, err := cce.Create(cceCreateArgs)
+		if err != nil {
+			return err
+		}
+
+		if !cceCreateArgs.Wait {
+			fmt.Printf("Creation of cluster %s (%s) submitted\n", cluster.Metadata.Name, cluster.Metadata.Id)
+			return nil
+		}
+		return formats.PrintFormatted(format, []cce.Cluster{{Clusters: *cluster}}, clustersTableView())
+	},
+}
+
+var cceCreateArgs = cce.CreateArgs{
+	SpecFile:     "",
+	Wait:         false,
+	Timeout:      45 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	cceCmd.AddCommand(cceCreateCmd)
+
+	cceCreateCmd.Flags().StringVarP(&cceCreateArgs.SpecFile, "file", "f", cceCreateArgs.SpecFile, "YAML file describing the cluster")
+	cceCreateCmd.Flags().BoolVar(&cceCreateArgs.Wait, "wait", cceCreateArgs.Wait, "Wait until the cluster is available and print it")
+	cceCreateCmd.Flags().DurationVar(&cceCreateArgs.Timeout, "timeout", cceCreateArgs.Timeout, "Maximum time to wait when --wait is set")
+	_ = cceCreateCmd.MarkFlagRequired("file")
+	initFlagFormat(cceCreateCmd)
+}
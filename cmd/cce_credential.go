@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"otc-cli/services/cce"
+
+	"github.com/spf13/cobra"
+)
+
+var cceCredentialCmd = &cobra.Command{
+	Use:   "credential <cluster-name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Print a short-lived credential for a CCE cluster",
+	Long: `Print a short-lived client certificate for a CCE cluster as a
+client.authentication.k8s.io/v1 ExecCredential.
+
+This command is meant to be run by kubectl as an exec credential plugin, as
+configured by "otc cce config --exec". The certificate is requested with the
+current session from "otc login" and cached until shortly before it expires.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceCredentialArgs.ClusterName = args[0]
+
+		credential, err := cce.Credential(cceCredentialArgs)
+		if err != nil {
+			return fmt.Errorf("failed to get credential for CCE cluster '%s': %w", args[0], err)
+		}
+
+		return json.NewEncoder(os.Stdout).Encode(credential)
+	},
+}
+
+var cceCredentialArgs = cce.CredentialArgs{
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	cceCmd.AddCommand(cceCredentialCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/spf13/cobra"
+)
+
+var cceDeleteCmd = &cobra.Command{
+	Use:   "delete <cluster-name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Delete a CCE cluster",
+	Long: `Delete a CCE cluster together with its nodes.
+
+The cluster has to be confirmed interactively unless --yes is given. EVS disks
+of persistent volumes and load balancers of services and ingresses are kept
+unless --delete-evs or --delete-elb is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceDeleteArgs.ClusterName = args[0]
+		cceDeleteArgs.Confirm = confirmClusterDeletion
+
+		if err := cce.Delete(cceDeleteArgs); err != nil {
+			return err
+		}
+		if cceDeleteArgs.Wait {
+			fmt.Println("Cluster deleted")
+		} else {
+			fmt.Println("Cluster deletion submitted")
+		}
+		return nil
+	},
+}
+
+var cceDeleteArgs = cce.DeleteArgs{
+	DeleteEVS:    false,
+	DeleteELB:    false,
+	Wait:         false,
+	Timeout:      30 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+var cceDeleteYes bool
+
+func init() {
+	cceCmd.AddCommand(cceDeleteCmd)
+
+	cceDeleteCmd.Flags().BoolVar(&cceDeleteArgs.DeleteEVS, "delete-evs", cceDeleteArgs.DeleteEVS, "Also delete EVS disks of persistent volumes")
+	cceDeleteCmd.Flags().BoolVar(&cceDeleteArgs.DeleteELB, "delete-elb", cceDeleteArgs.DeleteELB, "Also delete load balancers of services and ingresses")
+	cceDeleteCmd.Flags().BoolVar(&cceDeleteArgs.Wait, "wait", cceDeleteArgs.Wait, "Wait until the cluster is deleted")
+	cceDeleteCmd.Flags().DurationVar(&cceDeleteArgs.Timeout, "timeout", cceDeleteArgs.Timeout, "Maximum time to wait when --wait is set")
+	cceDeleteCmd.Flags().BoolVarP(&cceDeleteYes, "yes", "y", cceDeleteYes, "Do not ask for confirmation")
+}
+
+func confirmClusterDeletion(cluster cce.Cluster) (bool, error) {
+	fmt.Println("The following cluster and its nodes will be deleted:")
+	if err := formats.PrintFormatted("table", []cce.Cluster{cluster}, clustersTableView()); err != nil {
+		return false, err
+	}
+
+	if cceDeleteYes {
+		return true, nil
+	}
+	return confirm(fmt.Sprintf("Delete cluster %s?", cluster.Metadata.Name))
+}
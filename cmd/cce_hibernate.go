@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/errs"
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/spf13/cobra"
+)
+
+var cceHibernateCmd = &cobra.Command{
+	Use:   "hibernate <cluster-name|id>...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Hibernate CCE clusters",
+	Long: `Hibernate CCE clusters.
+
+Hibernation stops the master and worker nodes of a cluster, so that only its
+storage is billed. Workloads stop running until the cluster is woken up again
+with "otc cce awake".`,
+	Example: `  otc cce hibernate dev-cluster test-cluster --wait`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceHibernateArgs.ClusterNames = args
+		return printClusterActionResults(cce.HibernateClusters(cceHibernateArgs))
+	},
+}
+
+var cceAwakeCmd = &cobra.Command{
+	Use:     "awake <cluster-name|id>...",
+	Args:    cobra.MinimumNArgs(1),
+	Short:   "Wake up hibernated CCE clusters",
+	Example: `  otc cce awake dev-cluster test-cluster --wait`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceAwakeArgs.ClusterNames = args
+		return printClusterActionResults(cce.AwakeClusters(cceAwakeArgs))
+	},
+}
+
+var cceHibernateArgs = newClusterActionArgs()
+var cceAwakeArgs = newClusterActionArgs()
+
+func init() {
+	cceCmd.AddCommand(cceHibernateCmd)
+	cceCmd.AddCommand(cceAwakeCmd)
+
+	initFlagsClusterAction(cceHibernateCmd, &cceHibernateArgs)
+	initFlagsClusterAction(cceAwakeCmd, &cceAwakeArgs)
+}
+
+func newClusterActionArgs() cce.ClusterActionArgs {
+	return cce.ClusterActionArgs{
+		Wait:         false,
+		Timeout:      20 * time.Minute,
+		CommonConfig: commonConfig,
+	}
+}
+
+func initFlagsClusterAction(cmd *cobra.Command, args *cce.ClusterActionArgs) {
+	cmd.Flags().BoolVar(&args.Wait, "wait", args.Wait, "Wait until the clusters reach the target phase")
+	cmd.Flags().DurationVar(&args.Timeout, "timeout", args.Timeout, "Maximum time to wait for each cluster when --wait is set")
+	initFlagFormat(cmd)
+}
+
+// printClusterActionResults renders per-cluster results and fails if any
+// cluster failed.
+func printClusterActionResults(results []cce.ClusterActionResult, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if err := formats.PrintFormatted(format, results, clusterActionResultsTableView()); err != nil {
+		return err
+	}
+
+	if failed := errs.FailedCount(results); failed > 0 {
+		return fmt.Errorf("%d of %d clusters failed", failed, len(results))
+	}
+	return nil
+}
+
+func clusterActionResultsTableView() formats.View[cce.ClusterActionResult] {
+	return formats.View[cce.ClusterActionResult]{
+		Columns: []formats.Column[cce.ClusterActionResult]{
+			formats.Col("ID", func(r cce.ClusterActionResult) string {
+				return r.ID
+			}),
+			formats.Col("Name", func(r cce.ClusterActionResult) string {
+				return r.Name
+			}),
+			formats.Col("Status", func(r cce.ClusterActionResult) string {
+				return r.Phase
+			}),
+			formats.Col("Result", func(r cce.ClusterActionResult) string {
+				if r.Failed() {
+					return "failed: " + r.Error
+				}
+				return "ok"
+			}),
+		},
+	}
+}
@@ -2,11 +2,12 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
+	"time"
 
 	"otc-cli/formats"
 	"otc-cli/services/cce"
 
-	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
 	"github.com/spf13/cobra"
 )
 
@@ -16,36 +17,86 @@ var listCmd = &cobra.Command{
 	Short: "List CCE clusters",
 	Long:  `List all Cloud Container Engine (CCE) clusters in the specified region and project.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		clusters, err := cce.List(commonConfig)
-		if err != nil {
-			fmt.Printf("Error listing CCE clusters: %s\n", err)
-			return err
-		}
-
-		return formats.PrintFormatted(format, clusters, clustersTableView())
+		cceListArgs.WithNodeCount = wide
+		return printList(cmd, func() ([]cce.Cluster, error) {
+			return cce.List(cceListArgs)
+		}, clustersTableView, watchSpec[cce.Cluster]{
+			Key:    func(c cce.Cluster) string { return c.Metadata.Id },
+			Name:   func(c cce.Cluster) string { return c.Metadata.Name },
+			Status: func(c cce.Cluster) string { return c.Status.Phase },
+		})
 	},
 }
 
+var cceListArgs = cce.ListArgs{
+	WithNodeCount: false,
+	CommonConfig:  commonConfig,
+}
+
 func init() {
 	cceCmd.AddCommand(listCmd)
 	initFlagFormat(listCmd)
+	initFlagWide(listCmd)
+	initFlagsWatch(listCmd)
 }
 
-func clustersTableView() formats.View[clusters.Clusters] {
-	return formats.View[clusters.Clusters]{
-		Columns: []formats.Column[clusters.Clusters]{
-			formats.Col("ID", func(c clusters.Clusters) string {
+func clustersTableView() formats.View[cce.Cluster] {
+	return formats.View[cce.Cluster]{
+		Columns: []formats.Column[cce.Cluster]{
+			formats.Col("ID", func(c cce.Cluster) string {
 				return c.Metadata.Id
 			}),
-			formats.Col("Name", func(c clusters.Clusters) string {
+			formats.Col("Name", func(c cce.Cluster) string {
 				return c.Metadata.Name
 			}),
-			formats.Col("Status", func(c clusters.Clusters) string {
+			formats.Col("Status", func(c cce.Cluster) string {
 				return c.Status.Phase
 			}),
-			formats.Col("Version", func(c clusters.Clusters) string {
+			formats.Col("Version", func(c cce.Cluster) string {
 				return c.Spec.Version
 			}),
+			formats.Col("Flavor", func(c cce.Cluster) string {
+				return c.Spec.Flavor
+			}, formats.Wide[cce.Cluster](wide)),
+			formats.Col("Type", func(c cce.Cluster) string {
+				return c.Spec.Type
+			}, formats.Wide[cce.Cluster](wide)),
+			formats.Col("VPC / Subnet", func(c cce.Cluster) []string {
+				return []string{c.Spec.HostNetwork.VpcId, c.Spec.HostNetwork.SubnetId}
+			}, formats.Lines[cce.Cluster](), formats.Wide[cce.Cluster](wide)),
+			formats.Col("Container Network", func(c cce.Cluster) string {
+				return containerNetwork(c)
+			}, formats.Wide[cce.Cluster](wide)),
+			formats.Col("Service CIDR", func(c cce.Cluster) string {
+				return c.Spec.KubernetesSvcIpRange
+			}, formats.Wide[cce.Cluster](wide)),
+			formats.Col("Endpoints", func(c cce.Cluster) []string {
+				return c.EndpointAddresses()
+			}, formats.Lines[cce.Cluster](), formats.Wide[cce.Cluster](wide)),
+			formats.Col("Nodes", func(c cce.Cluster) string {
+				return nodeCount(c)
+			}, formats.Wide[cce.Cluster](wide)),
+			formats.Col("Billing", func(c cce.Cluster) string {
+				return c.BillingMode()
+			}, formats.Wide[cce.Cluster](wide)),
+			formats.Col("Created At", func(c cce.Cluster) time.Time {
+				return c.Created()
+			}, formats.Time[cce.Cluster](time.RFC3339), formats.Wide[cce.Cluster](wide)),
 		},
 	}
 }
+
+func containerNetwork(c cce.Cluster) string {
+	network := c.Spec.ContainerNetwork
+	if network.Cidr == "" {
+		return network.Mode
+	}
+	return fmt.Sprintf("%s (%s)", network.Mode, network.Cidr)
+}
+
+func nodeCount(c cce.Cluster) string {
+	if c.NodeCount == nil {
+		return ""
+	}
+	return strconv.Itoa(*c.NodeCount)
+}
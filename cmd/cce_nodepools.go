@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodepools"
+	"github.com/spf13/cobra"
+)
+
+var cceNodePoolsCmd = &cobra.Command{
+	Use:   "nodepools",
+	Short: "Manage node pools of CCE clusters",
+}
+
+var cceNodePoolsListCmd = &cobra.Command{
+	Use:   "list <cluster-name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "List node pools of a CCE cluster",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printList(cmd, func() ([]nodepools.NodePool, error) {
+			return cce.ListNodePools(args[0], commonConfig)
+		}, nodePoolsTableView, watchSpec[nodepools.NodePool]{
+			Key:    func(p nodepools.NodePool) string { return p.Metadata.Id },
+			Name:   func(p nodepools.NodePool) string { return p.Metadata.Name },
+			Status: func(p nodepools.NodePool) string { return nodePoolStatus(p) },
+		})
+	},
+}
+
+var cceNodePoolsShowCmd = &cobra.Command{
+	Use:   "show <cluster-name|id> <nodepool-name|id>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Show details of a node pool",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pool, err := cce.ShowNodePool(cce.NodePoolArgs{
+			ClusterName:  args[0],
+			NodePool:     args[1],
+			CommonConfig: commonConfig,
+		})
+		if err != nil {
+			return err
+		}
+		return formats.PrintDetail(format, *pool, nodePoolDetailView())
+	},
+}
+
+var cceNodePoolsScaleCmd = &cobra.Command{
+	Use:   "scale <cluster-name|id> <nodepool-name|id>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Change the number of nodes of a node pool",
+	Long: `Change the number of nodes of a node pool.
+
+With autoscaling enabled the count has to be within the autoscaling limits of
+the pool. With --wait the command returns once the pool has the requested
+number of nodes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceNodePoolsScaleArgs.ClusterName = args[0]
+		cceNodePoolsScaleArgs.NodePool = args[1]
+
+		pool, err := cce.ScaleNodePool(cceNodePoolsScaleArgs)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, []nodepools.NodePool{*pool}, nodePoolsTableView())
+	},
+}
+
+var cceNodePoolsCreateCmd = &cobra.Command{
+	Use:   "create <cluster-name|id> <nodepool-name>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Create a node pool",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceNodePoolsCreateArgs.ClusterName = args[0]
+		cceNodePoolsCreateArgs.NodePool = args[1]
+
+		pool, err := cce.CreateNodePool(cceNodePoolsCreateArgs)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, []nodepools.NodePool{*pool}, nodePoolsTableView())
+	},
+}
+
+var cceNodePoolsDeleteCmd = &cobra.Command{
+	Use:   "delete <cluster-name|id> <nodepool-name|id>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Delete a node pool and its nodes",
+	Long: `Delete a node pool and its nodes.
+
+The node pool has to be confirmed interactively unless --yes is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := cce.DeleteNodePool(cce.DeleteNodePoolArgs{
+			NodePoolArgs: cce.NodePoolArgs{
+				ClusterName:  args[0],
+				NodePool:     args[1],
+				CommonConfig: commonConfig,
+			},
+			Confirm: confirmNodePoolDeletion,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Println("Node pool deleted")
+		return nil
+	},
+}
+
+var cceNodePoolsScaleArgs = cce.ScaleNodePoolArgs{
+	NodePoolArgs: cce.NodePoolArgs{CommonConfig: commonConfig},
+	Count:        0,
+	Wait:         false,
+	Timeout:      20 * time.Minute,
+}
+
+var cceNodePoolsCreateArgs = cce.CreateNodePoolArgs{
+	NodePoolArgs:     cce.NodePoolArgs{CommonConfig: commonConfig},
+	AvailabilityZone: "random",
+	Count:            1,
+	RootVolumeSize:   50,
+	RootVolumeType:   "SSD",
+	DataVolumeSize:   100,
+	DataVolumeType:   "SSD",
+	Wait:             false,
+	Timeout:          20 * time.Minute,
+}
+
+var cceNodePoolsDeleteYes bool
+
+func init() {
+	cceCmd.AddCommand(cceNodePoolsCmd)
+	cceNodePoolsCmd.AddCommand(cceNodePoolsListCmd)
+	cceNodePoolsCmd.AddCommand(cceNodePoolsShowCmd)
+	cceNodePoolsCmd.AddCommand(cceNodePoolsScaleCmd)
+	cceNodePoolsCmd.AddCommand(cceNodePoolsCreateCmd)
+	cceNodePoolsCmd.AddCommand(cceNodePoolsDeleteCmd)
+
+	initFlagFormat(cceNodePoolsListCmd)
+	initFlagsWatch(cceNodePoolsListCmd)
+	initFlagFormat(cceNodePoolsShowCmd)
+
+	cceNodePoolsScaleCmd.Flags().IntVar(&cceNodePoolsScaleArgs.Count, "count", cceNodePoolsScaleArgs.Count, "Number of nodes")
+	cceNodePoolsScaleCmd.Flags().BoolVar(&cceNodePoolsScaleArgs.Wait, "wait", cceNodePoolsScaleArgs.Wait, "Wait until the node pool has the requested number of nodes")
+	cceNodePoolsScaleCmd.Flags().DurationVar(&cceNodePoolsScaleArgs.Timeout, "timeout", cceNodePoolsScaleArgs.Timeout, "Maximum time to wait when --wait is set")
+	_ = cceNodePoolsScaleCmd.MarkFlagRequired("count")
+	initFlagFormat(cceNodePoolsScaleCmd)
+
+	flags := cceNodePoolsCreateCmd.Flags()
+	flags.StringVar(&cceNodePoolsCreateArgs.Flavor, "flavor", cceNodePoolsCreateArgs.Flavor, "Flavor of the nodes")
+	flags.StringVar(&cceNodePoolsCreateArgs.AvailabilityZone, "az", cceNodePoolsCreateArgs.AvailabilityZone, "Availability zone of the nodes, \"random\" to spread them")
+	flags.StringVar(&cceNodePoolsCreateArgs.OS, "os", cceNodePoolsCreateArgs.OS, "Operating system of the nodes (default chosen by CCE)")
+	flags.StringVar(&cceNodePoolsCreateArgs.KeyPair, "key-pair", cceNodePoolsCreateArgs.KeyPair, "Key pair for logging in to the nodes")
+	flags.IntVar(&cceNodePoolsCreateArgs.Count, "count", cceNodePoolsCreateArgs.Count, "Initial number of nodes")
+	flags.IntVar(&cceNodePoolsCreateArgs.RootVolumeSize, "root-volume-size", cceNodePoolsCreateArgs.RootVolumeSize, "Root volume size in GB")
+	flags.StringVar(&cceNodePoolsCreateArgs.RootVolumeType, "root-volume-type", cceNodePoolsCreateArgs.RootVolumeType, "Root volume type, e.g. SATA, SAS or SSD")
+	flags.IntVar(&cceNodePoolsCreateArgs.DataVolumeSize, "data-volume-size", cceNodePoolsCreateArgs.DataVolumeSize, "Data volume size in GB")
+	flags.StringVar(&cceNodePoolsCreateArgs.DataVolumeType, "data-volume-type", cceNodePoolsCreateArgs.DataVolumeType, "Data volume type, e.g. SATA, SAS or SSD")
+	flags.IntVar(&cceNodePoolsCreateArgs.MinNodes, "min-nodes", cceNodePoolsCreateArgs.MinNodes, "Minimum number of nodes when autoscaling")
+	flags.IntVar(&cceNodePoolsCreateArgs.MaxNodes, "max-nodes", cceNodePoolsCreateArgs.MaxNodes, "Maximum number of nodes, enables autoscaling when set")
+	flags.BoolVar(&cceNodePoolsCreateArgs.Wait, "wait", cceNodePoolsCreateArgs.Wait, "Wait until the node pool has its initial nodes")
+	flags.DurationVar(&cceNodePoolsCreateArgs.Timeout, "timeout", cceNodePoolsCreateArgs.Timeout, "Maximum time to wait when --wait is set")
+	_ = cceNodePoolsCreateCmd.MarkFlagRequired("flavor")
+	_ = cceNodePoolsCreateCmd.MarkFlagRequired("key-pair")
+	initFlagFormat(cceNodePoolsCreateCmd)
+
+	cceNodePoolsDeleteCmd.Flags().BoolVarP(&cceNodePoolsDeleteYes, "yes", "y", cceNodePoolsDeleteYes, "Do not ask for confirmation")
+}
+
+func confirmNodePoolDeletion(pool nodepools.NodePool) (bool, error) {
+	fmt.Println("The following node pool and its nodes will be deleted:")
+	if err := formats.PrintFormatted("table", []nodepools.NodePool{pool}, nodePoolsTableView()); err != nil {
+		return false, err
+	}
+
+	if cceNodePoolsDeleteYes {
+		return true, nil
+	}
+	return confirm(fmt.Sprintf("Delete node pool %s?", pool.Metadata.Name))
+}
+
+// nodePoolStatus returns the phase of the pool. CCE leaves it empty for
+// pools that are available.
+func nodePoolStatus(p nodepools.NodePool) string {
+	if p.Status.Phase == "" {
+		return "Active"
+	}
+	return p.Status.Phase
+}
+
+func nodePoolAutoscaling(p nodepools.NodePool) string {
+	if !p.Spec.Autoscaling.Enable {
+		return "off"
+	}
+	return fmt.Sprintf("%d-%d", p.Spec.Autoscaling.MinNodeCount, p.Spec.Autoscaling.MaxNodeCount)
+}
+
+func nodePoolsTableView() formats.View[nodepools.NodePool] {
+	return formats.View[nodepools.NodePool]{
+		Columns: []formats.Column[nodepools.NodePool]{
+			formats.Col("ID", func(p nodepools.NodePool) string {
+				return p.Metadata.Id
+			}),
+			formats.Col("Name", func(p nodepools.NodePool) string {
+				return p.Metadata.Name
+			}),
+			formats.Col("Status", func(p nodepools.NodePool) string {
+				return nodePoolStatus(p)
+			}),
+			formats.Col("Flavor", func(p nodepools.NodePool) string {
+				return p.Spec.NodeTemplate.Flavor
+			}),
+			formats.Col("AZ", func(p nodepools.NodePool) string {
+				return p.Spec.NodeTemplate.Az
+			}),
+			formats.Col("Nodes", func(p nodepools.NodePool) string {
+				return fmt.Sprintf("%d/%d", p.Status.CurrentNode, p.Spec.InitialNodeCount)
+			}),
+			formats.Col("Autoscaling", func(p nodepools.NodePool) string {
+				return nodePoolAutoscaling(p)
+			}),
+		},
+	}
+}
+
+func nodePoolDetailView() formats.DetailView[nodepools.NodePool] {
+	return formats.DetailView[nodepools.NodePool]{
+		Fields: []formats.Column[nodepools.NodePool]{
+			formats.Col("ID", func(p nodepools.NodePool) string {
+				return p.Metadata.Id
+			}),
+			formats.Col("Name", func(p nodepools.NodePool) string {
+				return p.Metadata.Name
+			}),
+			formats.Col("Status", func(p nodepools.NodePool) string {
+				return nodePoolStatus(p)
+			}),
+			formats.Col("Type", func(p nodepools.NodePool) string {
+				return p.Spec.Type
+			}),
+			formats.Col("Flavor", func(p nodepools.NodePool) string {
+				return p.Spec.NodeTemplate.Flavor
+			}),
+			formats.Col("AZ", func(p nodepools.NodePool) string {
+				return p.Spec.NodeTemplate.Az
+			}),
+			formats.Col("OS", func(p nodepools.NodePool) string {
+				return p.Spec.NodeTemplate.Os
+			}),
+			formats.Col("Key Pair", func(p nodepools.NodePool) string {
+				return p.Spec.NodeTemplate.Login.SshKey
+			}),
+			formats.Col("Current Nodes", func(p nodepools.NodePool) string {
+				return strconv.Itoa(p.Status.CurrentNode)
+			}),
+			formats.Col("Requested Nodes", func(p nodepools.NodePool) string {
+				return strconv.Itoa(p.Spec.InitialNodeCount)
+			}),
+			formats.Col("Autoscaling", func(p nodepools.NodePool) string {
+				return nodePoolAutoscaling(p)
+			}),
+			formats.Col("Root Volume", func(p nodepools.NodePool) string {
+				v := p.Spec.NodeTemplate.RootVolume
+				return fmt.Sprintf("%d GB %s", v.Size, v.VolumeType)
+			}),
+			formats.Col("Data Volumes", func(p nodepools.NodePool) []string {
+				var volumes []string
+				for _, v := range p.Spec.NodeTemplate.DataVolumes {
+					volumes = append(volumes, fmt.Sprintf("%d GB %s", v.Size, v.VolumeType))
+				}
+				return volumes
+			}, formats.Lines[nodepools.NodePool]()),
+			formats.Col("Kubernetes Labels", func(p nodepools.NodePool) map[string]string {
+				return p.Spec.NodeTemplate.K8sTags
+			}, formats.KeyValues[nodepools.NodePool]()),
+			formats.Col("Taints", func(p nodepools.NodePool) []string {
+				var taints []string
+				for _, t := range p.Spec.NodeTemplate.Taints {
+					taints = append(taints, fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect))
+				}
+				return taints
+			}, formats.Lines[nodepools.NodePool]()),
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
+	"github.com/spf13/cobra"
+)
+
+var cceNodesCmd = &cobra.Command{
+	Use:   "nodes",
+	Short: "Manage nodes of CCE clusters",
+}
+
+var cceNodesListCmd = &cobra.Command{
+	Use:   "list <cluster-name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "List nodes of a CCE cluster",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceNodesListArgs.ClusterName = args[0]
+		return printList(cmd, func() ([]nodes.Nodes, error) {
+			return cce.ListNodes(cceNodesListArgs)
+		}, nodesTableView, watchSpec[nodes.Nodes]{
+			Key:    func(n nodes.Nodes) string { return n.Metadata.Id },
+			Name:   func(n nodes.Nodes) string { return n.Metadata.Name },
+			Status: func(n nodes.Nodes) string { return n.Status.Phase },
+		})
+	},
+}
+
+var cceNodesListArgs = cce.NodesListArgs{
+	NodePool:     "",
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	cceCmd.AddCommand(cceNodesCmd)
+	cceNodesCmd.AddCommand(cceNodesListCmd)
+
+	cceNodesListCmd.Flags().StringVar(&cceNodesListArgs.NodePool, "nodepool", cceNodesListArgs.NodePool, "Only list nodes of the node pool with this name or ID")
+	initFlagFormat(cceNodesListCmd)
+	initFlagsWatch(cceNodesListCmd)
+}
+
+func nodesTableView() formats.View[nodes.Nodes] {
+	return formats.View[nodes.Nodes]{
+		Columns: []formats.Column[nodes.Nodes]{
+			formats.Col("ID", func(n nodes.Nodes) string {
+				return n.Metadata.Id
+			}),
+			formats.Col("Name", func(n nodes.Nodes) string {
+				return n.Metadata.Name
+			}),
+			formats.Col("Status", func(n nodes.Nodes) string {
+				return n.Status.Phase
+			}),
+			formats.Col("Flavor", func(n nodes.Nodes) string {
+				return n.Spec.Flavor
+			}),
+			formats.Col("AZ", func(n nodes.Nodes) string {
+				return n.Spec.Az
+			}),
+			formats.Col("Private IP", func(n nodes.Nodes) string {
+				return n.Status.PrivateIP
+			}),
+			formats.Col("Public IP", func(n nodes.Nodes) string {
+				return n.Status.PublicIP
+			}),
+			formats.Col("OS", func(n nodes.Nodes) string {
+				return n.Spec.Os
+			}),
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/spf13/cobra"
+)
+
+var cceShowCmd = &cobra.Command{
+	Use:   "show <cluster-name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Show details of a CCE cluster",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cluster, err := cce.Show(args[0], commonConfig)
+		if err != nil {
+			return err
+		}
+		return formats.PrintDetail(format, *cluster, clusterDetailView())
+	},
+}
+
+func init() {
+	cceCmd.AddCommand(cceShowCmd)
+	initFlagFormat(cceShowCmd)
+}
+
+func clusterDetailView() formats.DetailView[cce.Cluster] {
+	return formats.DetailView[cce.Cluster]{
+		Fields: []formats.Column[cce.Cluster]{
+			formats.Col("ID", func(c cce.Cluster) string {
+				return c.Metadata.Id
+			}),
+			formats.Col("Name", func(c cce.Cluster) string {
+				return c.Metadata.Name
+			}),
+			formats.Col("Description", func(c cce.Cluster) string {
+				return c.Spec.Description
+			}),
+			formats.Col("Status", func(c cce.Cluster) string {
+				return c.Status.Phase
+			}),
+			formats.Col("Status Reason", func(c cce.Cluster) string {
+				return c.Status.Reason
+			}),
+			formats.Col("Version", func(c cce.Cluster) string {
+				return c.Spec.Version
+			}),
+			formats.Col("Category", func(c cce.Cluster) string {
+				return c.Spec.Category
+			}),
+			formats.Col("Type", func(c cce.Cluster) string {
+				return c.Spec.Type
+			}),
+			formats.Col("Flavor", func(c cce.Cluster) string {
+				return c.Spec.Flavor
+			}),
+			formats.Col("Master AZs", func(c cce.Cluster) []string {
+				var zones []string
+				for _, m := range c.Spec.Masters {
+					zones = append(zones, m.AvailabilityZone)
+				}
+				return zones
+			}, formats.Lines[cce.Cluster]()),
+			formats.Col("VPC", func(c cce.Cluster) string {
+				return c.Spec.HostNetwork.VpcId
+			}),
+			formats.Col("Subnet", func(c cce.Cluster) string {
+				return c.Spec.HostNetwork.SubnetId
+			}),
+			formats.Col("Security Group", func(c cce.Cluster) string {
+				return c.Spec.HostNetwork.SecurityGroupId
+			}),
+			formats.Col("Container Network", func(c cce.Cluster) string {
+				return containerNetwork(c)
+			}),
+			formats.Col("Service CIDR", func(c cce.Cluster) string {
+				return c.Spec.KubernetesSvcIpRange
+			}),
+			formats.Col("Kube Proxy Mode", func(c cce.Cluster) string {
+				return c.Spec.KubeProxyMode
+			}),
+			formats.Col("Authentication", func(c cce.Cluster) string {
+				return c.Spec.Authentication.Mode
+			}),
+			formats.Col("Public Access CIDRs", func(c cce.Cluster) string {
+				if c.Spec.PublicAccess == nil {
+					return ""
+				}
+				return strings.Join(c.Spec.PublicAccess.Cidrs, ", ")
+			}),
+			formats.Col("Endpoints", func(c cce.Cluster) []string {
+				return c.EndpointAddresses()
+			}, formats.Lines[cce.Cluster]()),
+			formats.Col("Nodes", func(c cce.Cluster) string {
+				return nodeCount(c)
+			}),
+			formats.Col("Billing", func(c cce.Cluster) string {
+				return c.BillingMode()
+			}),
+			formats.Col("Labels", func(c cce.Cluster) map[string]string {
+				return c.Metadata.Labels
+			}, formats.KeyValues[cce.Cluster]()),
+			formats.Col("Created At", func(c cce.Cluster) time.Time {
+				return c.Created()
+			}, formats.Time[cce.Cluster](time.RFC3339)),
+		},
+	}
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+
+	"otc-cli/formats"
+	"otc-cli/services/cce"
+
+	"github.com/spf13/cobra"
+)
+
+var cceUpgradeCheckCmd = &cobra.Command{
+	Use:   "upgrade-check <cluster-name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Check which versions a cluster can be upgraded to",
+	Long: `Check which versions a cluster can be upgraded to and which of its add-ons
+need an upgrade.
+
+Add-ons are checked against the newest target version unless --target is
+given. Blocking add-ons do not support the target version and have to be
+upgraded before the cluster; the others merely have a newer version.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cceUpgradeCheckArgs.ClusterName = args[0]
+
+		check, err := cce.CheckUpgrade(cceUpgradeCheckArgs)
+		if err != nil {
+			return err
+		}
+
+		// Structured formats include the add-ons in the check itself.
+		if err := formats.PrintDetail(format, *check, upgradeCheckDetailView()); err != nil {
+			return err
+		}
+		if format != "table" {
+			return nil
+		}
+		if len(check.Addons) == 0 {
+			fmt.Println("No add-ons need an upgrade")
+			return nil
+		}
+		return formats.PrintFormatted(format, check.Addons, addonUpgradesTableView())
+	},
+}
+
+var cceUpgradeCheckArgs = cce.UpgradeCheckArgs{
+	TargetVersion: "",
+	CommonConfig:  commonConfig,
+}
+
+func init() {
+	cceCmd.AddCommand(cceUpgradeCheckCmd)
+	cceUpgradeCheckCmd.Flags().StringVar(&cceUpgradeCheckArgs.TargetVersion, "target", cceUpgradeCheckArgs.TargetVersion, "Target version to check the add-ons against (default the newest)")
+	initFlagFormat(cceUpgradeCheckCmd)
+}
+
+func upgradeCheckDetailView() formats.DetailView[cce.UpgradeCheck] {
+	return formats.DetailView[cce.UpgradeCheck]{
+		Fields: []formats.Column[cce.UpgradeCheck]{
+			formats.Col("Cluster", func(c cce.UpgradeCheck) string {
+				return c.Cluster
+			}),
+			formats.Col("Current Version", func(c cce.UpgradeCheck) string {
+				return c.CurrentVersion
+			}),
+			formats.Col("Target Versions", func(c cce.UpgradeCheck) []string {
+				return c.TargetVersions
+			}, formats.Lines[cce.UpgradeCheck]()),
+			formats.Col("Checked Against", func(c cce.UpgradeCheck) string {
+				return c.TargetVersion
+			}),
+		},
+	}
+}
+
+func addonUpgradesTableView() formats.View[cce.AddonUpgrade] {
+	return formats.View[cce.AddonUpgrade]{
+		Columns: []formats.Column[cce.AddonUpgrade]{
+			formats.Col("Add-on", func(a cce.AddonUpgrade) string {
+				return a.Name
+			}),
+			formats.Col("Current", func(a cce.AddonUpgrade) string {
+				return a.CurrentVersion
+			}),
+			formats.Col("Latest", func(a cce.AddonUpgrade) string {
+				return a.LatestVersion
+			}),
+			formats.Col("Blocking", func(a cce.AddonUpgrade) bool {
+				return a.Blocking
+			}, formats.BoolYesNo[cce.AddonUpgrade]()),
+		},
+	}
+}
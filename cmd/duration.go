@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// dayDuration is a duration flag that also accepts days and weeks ("14d",
+// "2w") in addition to the units of time.ParseDuration.
+type dayDuration time.Duration
+
+var _ pflag.Value = (*dayDuration)(nil)
+
+func (d *dayDuration) Set(value string) error {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if count, ok := strings.CutSuffix(value, suffix); ok {
+			if n, err := strconv.Atoi(count); err == nil {
+				*d = dayDuration(time.Duration(n) * unit)
+				return nil
+			}
+		}
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = dayDuration(parsed)
+	return nil
+}
+
+func (d *dayDuration) String() string {
+	duration := time.Duration(*d)
+	if duration != 0 && duration%(24*time.Hour) == 0 {
+		return strconv.Itoa(int(duration/(24*time.Hour))) + "d"
+	}
+	return duration.String()
+}
+
+func (d *dayDuration) Type() string {
+	return "duration"
+}
+
+// dayDurationVar registers a duration flag accepting days and weeks.
+func dayDurationVar(flags *pflag.FlagSet, p *time.Duration, name string, usage string) {
+	flags.Var((*dayDuration)(p), name, usage)
+}
@@ -1,6 +1,13 @@
 package cmd
 
 import (
+	"fmt"
+	"time"
+
+	"otc-cli/errs"
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+
 	"github.com/spf13/cobra"
 )
 
@@ -13,3 +20,65 @@ var ecsCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(ecsCmd)
 }
+
+func initFlagsServerSelector(cmd *cobra.Command, selector *ecs.ServerSelector, allowAll bool) {
+	cmd.Flags().StringToStringVar(&selector.Tags, "selector", selector.Tags, "Select servers by tag (key=value), can be repeated")
+	if allowAll {
+		cmd.Flags().BoolVar(&selector.All, "all", selector.All, "Act on all servers matching the given names, patterns or selector")
+	}
+}
+
+func newServerActionArgs() ecs.ActionArgs {
+	return ecs.ActionArgs{
+		Parallelism:  5,
+		Wait:         false,
+		Timeout:      10 * time.Minute,
+		CommonConfig: commonConfig,
+	}
+}
+
+func initFlagsServerAction(cmd *cobra.Command, args *ecs.ActionArgs) {
+	initFlagsServerSelector(cmd, &args.Selector, true)
+	cmd.Flags().IntVar(&args.Parallelism, "parallel", args.Parallelism, "Number of servers processed concurrently")
+	cmd.Flags().BoolVar(&args.Wait, "wait", args.Wait, "Wait until the servers reach the target state")
+	cmd.Flags().DurationVar(&args.Timeout, "timeout", args.Timeout, "Maximum time to wait for each server when --wait is set")
+	initFlagFormat(cmd)
+}
+
+// printActionResults renders per-server results and fails if any server failed.
+func printActionResults(results []ecs.ActionResult, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if err := formats.PrintFormatted(format, results, actionResultsTableView()); err != nil {
+		return err
+	}
+
+	if failed := errs.FailedCount(results); failed > 0 {
+		return fmt.Errorf("%d of %d servers failed", failed, len(results))
+	}
+	return nil
+}
+
+func actionResultsTableView() formats.View[ecs.ActionResult] {
+	return formats.View[ecs.ActionResult]{
+		Columns: []formats.Column[ecs.ActionResult]{
+			formats.Col("ID", func(r ecs.ActionResult) string {
+				return r.ID
+			}),
+			formats.Col("Name", func(r ecs.ActionResult) string {
+				return r.Name
+			}),
+			formats.Col("Status", func(r ecs.ActionResult) string {
+				return r.Status
+			}),
+			formats.Col("Result", func(r ecs.ActionResult) string {
+				if r.Failed() {
+					return "failed: " + r.Error
+				}
+				return "ok"
+			}),
+		},
+	}
+}
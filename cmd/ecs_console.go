@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsConsoleLogCmd = &cobra.Command{
+	Use:   "console-log <name|id|pattern>",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Print the serial console log of an ECS server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		consoleLogArgs.Selector.Patterns = args
+		return ecs.ConsoleLog(consoleLogArgs, os.Stdout)
+	},
+}
+
+var ecsConsoleURLCmd = &cobra.Command{
+	Use:   "console-url <name|id|pattern>",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Print the remote VNC console URL of an ECS server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		consoleURLSelector.Patterns = args
+		url, err := ecs.ConsoleURL(consoleURLSelector, commonConfig)
+		if err != nil {
+			return err
+		}
+
+		if consoleURLOpen {
+			return openBrowser(url)
+		}
+		fmt.Println(url)
+		return nil
+	},
+}
+
+var consoleLogArgs = ecs.ConsoleLogArgs{
+	Lines:        0,
+	Follow:       false,
+	Interval:     2 * time.Second,
+	CommonConfig: commonConfig,
+}
+
+var consoleURLSelector = ecs.ServerSelector{}
+var consoleURLOpen bool
+
+func init() {
+	ecsCmd.AddCommand(ecsConsoleLogCmd)
+	ecsCmd.AddCommand(ecsConsoleURLCmd)
+
+	initFlagsServerSelector(ecsConsoleLogCmd, &consoleLogArgs.Selector, false)
+	ecsConsoleLogCmd.Flags().IntVarP(&consoleLogArgs.Lines, "lines", "n", consoleLogArgs.Lines, "Number of lines from the end of the log to print (0 prints everything)")
+	ecsConsoleLogCmd.Flags().BoolVarP(&consoleLogArgs.Follow, "follow", "f", consoleLogArgs.Follow, "Keep printing new log lines as they appear")
+	ecsConsoleLogCmd.Flags().DurationVar(&consoleLogArgs.Interval, "interval", consoleLogArgs.Interval, "Polling interval when following the log")
+
+	initFlagsServerSelector(ecsConsoleURLCmd, &consoleURLSelector, false)
+	ecsConsoleURLCmd.Flags().BoolVar(&consoleURLOpen, "open", consoleURLOpen, "Open the console in the default browser")
+}
+
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
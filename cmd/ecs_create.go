@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Create an ECS server",
+	Long: `Create an ECS server from command line flags or a YAML spec file.
+
+The spec file uses the same fields as the flags (name, flavor, image, vpc,
+subnet, security_groups, key_pair, availability_zone, volume_type,
+root_volume_size, data_volume_sizes, user_data_file and tags). Flags given on
+the command line override values from the file.`,
+	Example: `  otc ecs create web-01 --flavor s3.large.2 --image "Standard_Ubuntu_22.04_latest" --subnet web --key-pair deploy --wait
+  otc ecs create -f web-01.yaml --wait`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			ecsCreateArgs.Spec.Name = args[0]
+		}
+
+		result, err := ecs.Create(ecsCreateArgs)
+		if err != nil {
+			return err
+		}
+
+		if result.Server == nil {
+			fmt.Printf("Server creation submitted as job %s\n", result.JobID)
+			return nil
+		}
+		return formats.PrintFormatted(format, []ecs.Server{{Server: *result.Server}}, serversTableView())
+	},
+}
+
+var ecsCreateArgs = ecs.CreateArgs{
+	Spec:         ecs.CreateSpec{},
+	SpecFile:     "",
+	Wait:         false,
+	Timeout:      15 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsCreateCmd)
+
+	spec := &ecsCreateArgs.Spec
+	ecsCreateCmd.Flags().StringVarP(&ecsCreateArgs.SpecFile, "file", "f", ecsCreateArgs.SpecFile, "YAML file describing the server")
+	ecsCreateCmd.Flags().StringVar(&spec.Flavor, "flavor", spec.Flavor, "Flavor name or ID")
+	ecsCreateCmd.Flags().StringVar(&spec.Image, "image", spec.Image, "Image name or ID")
+	ecsCreateCmd.Flags().StringVar(&spec.VPC, "vpc", spec.VPC, "VPC name or ID, needed when the subnet name is not unique")
+	ecsCreateCmd.Flags().StringVar(&spec.Subnet, "subnet", spec.Subnet, "Subnet name or ID")
+	ecsCreateCmd.Flags().StringSliceVar(&spec.SecurityGroups, "security-group", spec.SecurityGroups, "Security group name or ID, can be repeated")
+	ecsCreateCmd.Flags().StringVar(&spec.KeyPair, "key-pair", spec.KeyPair, "Name of the key pair injected into the server")
+	ecsCreateCmd.Flags().StringVar(&spec.AvailabilityZone, "az", spec.AvailabilityZone, "Availability zone")
+	ecsCreateCmd.Flags().StringVar(&spec.VolumeType, "volume-type", spec.VolumeType, "Volume type of the root and data volumes (default SSD)")
+	ecsCreateCmd.Flags().IntVar(&spec.RootVolumeSize, "root-volume-size", spec.RootVolumeSize, "Root volume size in GB (default is the image minimum)")
+	ecsCreateCmd.Flags().IntSliceVar(&spec.DataVolumeSizes, "data-volume-size", spec.DataVolumeSizes, "Size in GB of a data volume to attach, can be repeated")
+	ecsCreateCmd.Flags().StringVar(&spec.UserDataFile, "user-data", spec.UserDataFile, "Path to a user data (cloud-init) file")
+	ecsCreateCmd.Flags().StringToStringVar(&spec.Tags, "tag", spec.Tags, "Tag (key=value) to set on the server, can be repeated")
+	ecsCreateCmd.Flags().BoolVar(&ecsCreateArgs.Wait, "wait", ecsCreateArgs.Wait, "Wait until the server is ACTIVE and print it")
+	ecsCreateCmd.Flags().DurationVar(&ecsCreateArgs.Timeout, "timeout", ecsCreateArgs.Timeout, "Maximum time to wait when --wait is set")
+	initFlagFormat(ecsCreateCmd)
+}
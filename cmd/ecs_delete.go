@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/config"
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsDeleteCmd = &cobra.Command{
+	Use:   "delete <name|id|pattern>...",
+	Short: "Delete ECS servers",
+	Long: `Delete ECS servers.
+
+The servers to delete are listed and have to be confirmed interactively unless
+--yes is given. Servers carrying the protection tag (by default "protected",
+configurable per cloud as ecs.protection_tag in clouds.yaml) are never deleted.`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if cloud := commonConfig.SelectedCloud; cloud != nil {
+			config.SetIfEmpty(&ecsDeleteArgs.ProtectionTag, cloud.ECS.ProtectionTag)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ecsDeleteArgs.Selector.Patterns = args
+		ecsDeleteArgs.Confirm = confirmServerDeletion
+
+		if err := ecs.Delete(ecsDeleteArgs); err != nil {
+			return err
+		}
+		fmt.Println("Servers deleted")
+		return nil
+	},
+}
+
+var ecsDeleteArgs = ecs.DeleteArgs{
+	ProtectionTag: "",
+	DeleteVolumes: false,
+	ReleaseEIPs:   false,
+	Timeout:       10 * time.Minute,
+	CommonConfig:  commonConfig,
+}
+
+var ecsDeleteYes bool
+
+func init() {
+	ecsCmd.AddCommand(ecsDeleteCmd)
+
+	initFlagsServerSelector(ecsDeleteCmd, &ecsDeleteArgs.Selector, true)
+	ecsDeleteCmd.Flags().StringVar(&ecsDeleteArgs.ProtectionTag, "protection-tag", ecsDeleteArgs.ProtectionTag, "Tag key marking servers that must not be deleted (default \""+ecs.DefaultProtectionTag+"\")")
+	ecsDeleteCmd.Flags().BoolVar(&ecsDeleteArgs.DeleteVolumes, "delete-volumes", ecsDeleteArgs.DeleteVolumes, "Also delete data volumes attached to the servers")
+	ecsDeleteCmd.Flags().BoolVar(&ecsDeleteArgs.ReleaseEIPs, "release-eips", ecsDeleteArgs.ReleaseEIPs, "Also release elastic IPs bound to the servers")
+	ecsDeleteCmd.Flags().DurationVar(&ecsDeleteArgs.Timeout, "timeout", ecsDeleteArgs.Timeout, "Maximum time to wait for the servers to be deleted")
+	ecsDeleteCmd.Flags().BoolVarP(&ecsDeleteYes, "yes", "y", ecsDeleteYes, "Do not ask for confirmation")
+}
+
+func confirmServerDeletion(plan []ecs.DeletePlanItem) (bool, error) {
+	fmt.Println("The following servers will be deleted:")
+	if err := formats.PrintFormatted("table", plan, deletePlanTableView()); err != nil {
+		return false, err
+	}
+
+	if ecsDeleteYes {
+		return true, nil
+	}
+	return confirm(fmt.Sprintf("Delete %d servers?", len(plan)))
+}
+
+func deletePlanTableView() formats.View[ecs.DeletePlanItem] {
+	return formats.View[ecs.DeletePlanItem]{
+		Columns: []formats.Column[ecs.DeletePlanItem]{
+			formats.Col("ID", func(i ecs.DeletePlanItem) string {
+				return i.ID
+			}),
+			formats.Col("Name", func(i ecs.DeletePlanItem) string {
+				return i.Name
+			}),
+			formats.Col("Status", func(i ecs.DeletePlanItem) string {
+				return i.Status
+			}),
+			formats.Col("Volumes", func(i ecs.DeletePlanItem) []string {
+				return i.Volumes
+			}, formats.Lines[ecs.DeletePlanItem]()),
+			formats.Col("EIPs", func(i ecs.DeletePlanItem) []string {
+				return i.EIPs
+			}, formats.Lines[ecs.DeletePlanItem]()),
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsFlavorsCmd = &cobra.Command{
+	Use:   "flavors",
+	Short: "Browse ECS flavors",
+}
+
+var ecsFlavorsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List ECS flavors",
+	Long: `List ECS flavors.
+
+The Status column is the default sale status of a flavor; AZ Availability
+lists the availability zones where it differs (normal, sellout, abandon or
+promotion). With --az, only flavors that can be ordered in that zone are
+listed.
+
+The flavor API does not report prices. When hourly prices by flavor name
+are configured in ecs.flavor_prices of the cloud in clouds.yaml, with the
+currency from ecs.price_currency, they are shown in the Configured Price/h
+column. These prices are not checked against the OTC price list and go
+stale when it changes.`,
+	Example: `  otc ecs flavors list --min-vcpu 4 --min-ram 16 --az eu-de-01 --family s3
+  otc ecs flavors list --family c4 --format json`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if cloud := commonConfig.SelectedCloud; cloud != nil {
+			flavorsListArgs.Prices = cloud.ECS.FlavorPrices
+			flavorsListArgs.PriceCurrency = cloud.ECS.PriceCurrency
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flavorList, err := ecs.ListFlavors(flavorsListArgs)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, flavorList, flavorsTableView())
+	},
+}
+
+var flavorsListArgs = ecs.ListFlavorsArgs{
+	AvailabilityZone: "",
+	MinVCPUs:         0,
+	MinRAM:           0,
+	Family:           "",
+	CommonConfig:     commonConfig,
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsFlavorsCmd)
+	ecsFlavorsCmd.AddCommand(ecsFlavorsListCmd)
+
+	ecsFlavorsListCmd.Flags().StringVar(&flavorsListArgs.AvailabilityZone, "az", flavorsListArgs.AvailabilityZone, "Only list flavors available in the availability zone")
+	ecsFlavorsListCmd.Flags().IntVar(&flavorsListArgs.MinVCPUs, "min-vcpu", flavorsListArgs.MinVCPUs, "Minimum number of vCPUs")
+	ecsFlavorsListCmd.Flags().IntVar(&flavorsListArgs.MinRAM, "min-ram", flavorsListArgs.MinRAM, "Minimum RAM in GB")
+	ecsFlavorsListCmd.Flags().StringVar(&flavorsListArgs.Family, "family", flavorsListArgs.Family, "Only list flavors of the family, e.g. s3 or c4")
+	initFlagFormat(ecsFlavorsListCmd)
+}
+
+func flavorsTableView() formats.View[ecs.Flavor] {
+	return formats.View[ecs.Flavor]{
+		Columns: []formats.Column[ecs.Flavor]{
+			formats.Col("Name", func(f ecs.Flavor) string {
+				return f.Name
+			}),
+			formats.Col("vCPUs", func(f ecs.Flavor) int {
+				return f.VCPUCount()
+			}, formats.RightAlign[ecs.Flavor]()),
+			formats.Col("RAM (GB)", func(f ecs.Flavor) string {
+				return strconv.FormatFloat(float64(f.RAM)/1024, 'f', -1, 64)
+			}, formats.RightAlign[ecs.Flavor]()),
+			formats.Col("Generation", func(f ecs.Flavor) string {
+				return f.ExtraSpecs.Generation
+			}),
+			formats.Col("Performance Type", func(f ecs.Flavor) string {
+				return f.ExtraSpecs.PerformanceType
+			}),
+			formats.Col("Status", func(f ecs.Flavor) string {
+				return f.ExtraSpecs.OperationStatus
+			}),
+			formats.Col("AZ Availability", func(f ecs.Flavor) map[string]string {
+				return f.AZStatuses()
+			}, formats.KeyValues[ecs.Flavor]()),
+			formats.Col("Configured Price/h", func(f ecs.Flavor) string {
+				if f.ConfiguredPrice == nil {
+					return ""
+				}
+				return strings.TrimSpace(strconv.FormatFloat(f.ConfiguredPrice.PerHour, 'f', -1, 64) + " " + f.ConfiguredPrice.Currency)
+			}, formats.RightAlign[ecs.Flavor](), formats.Hidden[ecs.Flavor](len(flavorsListArgs.Prices) == 0)),
+		},
+	}
+}
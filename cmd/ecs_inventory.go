@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"otc-cli/config"
+	"otc-cli/services/ecs"
+	"otc-cli/services/inventory"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsInventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Generate an SSH config or Ansible inventory from ECS servers",
+	Long: `Generate an SSH config or Ansible inventory from ECS servers.
+
+The login user is taken from the ssh_user tag or metadata of a server, then
+derived from its image name, and falls back to --user. Every --group-by key
+present as a tag or metadata puts the server into the group <key>_<value>.
+Servers without an IPv4 address, e.g. still building, are skipped with a
+warning.
+
+With --output, ssh-config and ansible-ini are written into a block enclosed
+by marker comments named after the cloud; regenerating replaces only that
+block and keeps the rest of the file. Other formats replace the whole file.`,
+	Example: `  otc ecs inventory --format ssh-config --output ~/.ssh/config.d/otc
+  otc ecs inventory --format ansible-ini --group-by role,env --output hosts.ini`,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if cloud := commonConfig.SelectedCloud; cloud != nil {
+			config.SetIfEmpty(&inventoryArgs.User, cloud.SSH.User)
+			config.SetIfEmpty(&inventoryArgs.Address, cloud.SSH.Address)
+			config.SetIfEmpty(&inventoryOptions.JumpHost, cloud.SSH.JumpHost)
+			config.SetIfEmpty(&inventoryOptions.KeyDir, cloud.SSH.KeyDir)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hosts, warnings, err := ecs.Inventory(inventoryArgs)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+
+		var out bytes.Buffer
+		if err := inventory.Render(&out, inventoryFormat, hosts, inventoryOptions); err != nil {
+			return err
+		}
+
+		switch {
+		case inventoryOutput == "":
+			_, err = os.Stdout.Write(out.Bytes())
+			return err
+		case inventoryFormat == inventory.FormatSSHConfig || inventoryFormat == inventory.FormatAnsibleINI:
+			blockName := commonConfig.CloudName
+			config.SetIfEmpty(&blockName, "default")
+			err = inventory.WriteManagedBlock(inventoryOutput, blockName, out.String())
+		default:
+			err = os.WriteFile(inventoryOutput, out.Bytes(), 0o644)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Wrote %d hosts to %s\n", len(hosts), inventoryOutput)
+		return nil
+	},
+}
+
+var inventoryArgs = ecs.InventoryArgs{
+	ListArgs: ecs.ListArgs{
+		Filter:       "",
+		Limit:        0,
+		CommonConfig: commonConfig,
+	},
+	Address: "",
+	User:    "",
+	GroupBy: nil,
+}
+
+var inventoryOptions = inventory.Options{}
+var inventoryFormat = inventory.FormatSSHConfig
+var inventoryOutput string
+
+func init() {
+	ecsCmd.AddCommand(ecsInventoryCmd)
+
+	ecsInventoryCmd.Flags().StringVar(&inventoryFormat, "format", inventoryFormat, "Output format: "+strings.Join(inventory.Formats, ", "))
+	ecsInventoryCmd.Flags().StringVarP(&inventoryOutput, "output", "o", inventoryOutput, "Write to a file instead of stdout")
+	ecsInventoryCmd.Flags().StringVar(&inventoryArgs.Filter, "filter", inventoryArgs.Filter, "Filter servers by name")
+	ecsInventoryCmd.Flags().StringVar(&inventoryArgs.Address, "address", inventoryArgs.Address, "Address to use: floating or fixed (default floating)")
+	ecsInventoryCmd.Flags().StringVar(&inventoryArgs.User, "user", inventoryArgs.User, "Default login user")
+	ecsInventoryCmd.Flags().StringSliceVar(&inventoryArgs.GroupBy, "group-by", inventoryArgs.GroupBy, "Tag or metadata keys to group servers by")
+	ecsInventoryCmd.Flags().StringVar(&inventoryOptions.JumpHost, "jump-host", inventoryOptions.JumpHost, "Jump host for all servers")
+	ecsInventoryCmd.Flags().StringVar(&inventoryOptions.KeyDir, "key-dir", inventoryOptions.KeyDir, "Directory with private keys named after key pairs")
+}
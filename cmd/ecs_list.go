@@ -1,12 +1,13 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
 	"time"
 
 	"otc-cli/formats"
 	"otc-cli/services/ecs"
 
-	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
 	"github.com/spf13/cobra"
 )
 
@@ -15,17 +16,30 @@ var ecsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List ECS servers",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		servers, err := ecs.List(ecsListArgs)
-		if err != nil {
-			return err
-		}
-		return formats.PrintFormatted(format, servers, serversTableView())
+		ecsListArgs.WithTags = wide
+		// warn about failed name lookups once, not on every --watch refresh
+		warned := map[string]bool{}
+		return printList(cmd, func() ([]ecs.Server, error) {
+			serverList, warnings, err := ecs.List(ecsListArgs)
+			for _, warning := range warnings {
+				if !warned[warning.Error()] {
+					warned[warning.Error()] = true
+					fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+				}
+			}
+			return serverList, err
+		}, serversTableView, watchSpec[ecs.Server]{
+			Key:    func(s ecs.Server) string { return s.ID },
+			Name:   func(s ecs.Server) string { return s.Name },
+			Status: func(s ecs.Server) string { return s.Status },
+		})
 	},
 }
 
 var ecsListArgs = ecs.ListArgs{
 	Filter:       "",
 	Limit:        0,
+	Tags:         nil,
 	CommonConfig: commonConfig,
 }
 
@@ -34,30 +48,45 @@ func init() {
 
 	ecsListCmd.Flags().StringVar(&ecsListArgs.Filter, "filter", ecsListArgs.Filter, "Filter servers by name")
 	ecsListCmd.Flags().IntVar(&ecsListArgs.Limit, "limit", ecsListArgs.Limit, "Limit the number of servers listed")
+	ecsListCmd.Flags().StringToStringVar(&ecsListArgs.Tags, "tag", ecsListArgs.Tags, "Only list servers with the tag (key=value), can be repeated")
 	initFlagFormat(ecsListCmd)
+	initFlagWide(ecsListCmd)
+	initFlagsWatch(ecsListCmd)
 }
 
-func serversTableView() formats.View[servers.Server] {
-	return formats.View[servers.Server]{
-		Columns: []formats.Column[servers.Server]{
-			formats.Col("ID", func(s servers.Server) string {
+func serversTableView() formats.View[ecs.Server] {
+	return formats.View[ecs.Server]{
+		Columns: []formats.Column[ecs.Server]{
+			formats.Col("ID", func(s ecs.Server) string {
 				return s.ID
 			}),
-			formats.Col("Name", func(s servers.Server) string {
+			formats.Col("Name", func(s ecs.Server) string {
 				return s.Name
 			}),
-			formats.Col("Status", func(s servers.Server) string {
+			formats.Col("Status", func(s ecs.Server) string {
 				return s.Status
 			}),
-			formats.Col("Flavor", func(s servers.Server) string {
-				return s.Flavor["id"].(string)
+			formats.Col("Flavor", func(s ecs.Server) string {
+				if s.FlavorDetails != nil {
+					return s.FlavorDetails.String()
+				}
+				return ecs.FlavorID(s.Server)
 			}),
-			formats.Col("Image", func(s servers.Server) string {
-				return s.Image["id"].(string)
+			formats.Col("Image", func(s ecs.Server) string {
+				if s.ImageName != "" {
+					return s.ImageName
+				}
+				if id := ecs.ImageID(s.Server); id != "" {
+					return id
+				}
+				return "(boot volume)"
 			}),
-			formats.Col("Created At", func(s servers.Server) time.Time {
+			formats.Col("Created At", func(s ecs.Server) time.Time {
 				return s.Created
-			}, formats.Time[servers.Server](time.RFC3339)),
+			}, formats.Time[ecs.Server](time.RFC3339)),
+			formats.Col("Tags", func(s ecs.Server) map[string]string {
+				return s.Tags
+			}, formats.KeyValues[ecs.Server](), formats.Wide[ecs.Server](wide)),
 		},
 	}
 }
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsMetadataCmd = &cobra.Command{
+	Use:   "metadata",
+	Short: "Manage metadata of an ECS server",
+}
+
+var ecsMetadataGetCmd = &cobra.Command{
+	Use:   "get <name|id|pattern> [key...]",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Print the metadata of a server, or only the given keys",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metadata, err := ecs.GetMetadata(ecs.ServerSelector{Patterns: args[:1]}, args[1:], commonConfig)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, metadata, keyValueTableView())
+	},
+}
+
+var ecsMetadataSetCmd = &cobra.Command{
+	Use:   "set <name|id|pattern> key=value...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Create or update metadata of a server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		metadata, err := parseKeyValues(args[1:])
+		if err != nil {
+			return err
+		}
+		return ecs.SetMetadata(ecs.ServerSelector{Patterns: args[:1]}, metadata, commonConfig)
+	},
+}
+
+var ecsMetadataUnsetCmd = &cobra.Command{
+	Use:   "unset <name|id|pattern> key...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Remove metadata from a server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ecs.UnsetMetadata(ecs.ServerSelector{Patterns: args[:1]}, args[1:], commonConfig)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsMetadataCmd)
+	ecsMetadataCmd.AddCommand(ecsMetadataGetCmd)
+	ecsMetadataCmd.AddCommand(ecsMetadataSetCmd)
+	ecsMetadataCmd.AddCommand(ecsMetadataUnsetCmd)
+
+	initFlagFormat(ecsMetadataGetCmd)
+}
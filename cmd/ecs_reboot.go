@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var rebootEcsCommand = &cobra.Command{
+	Use:   "reboot <name|id|pattern>...",
+	Short: "Reboot ECS servers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rebootArgs.Selector.Patterns = args
+		return printActionResults(ecs.RebootServers(rebootArgs))
+	},
+}
+
+var rebootArgs = ecs.RebootArgs{
+	ActionArgs: newServerActionArgs(),
+	Hard:       false,
+}
+
+func init() {
+	ecsCmd.AddCommand(rebootEcsCommand)
+
+	initFlagsServerAction(rebootEcsCommand, &rebootArgs.ActionArgs)
+	rebootEcsCommand.Flags().BoolVar(&rebootArgs.Hard, "hard", rebootArgs.Hard, "Power cycle the servers instead of rebooting the OS")
+}
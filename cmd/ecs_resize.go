@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var resizeEcsCommand = &cobra.Command{
+	Use:   "resize <name|id|pattern>...",
+	Short: "Change the flavor of ECS servers",
+	Long: `Change the flavor of ECS servers.
+
+A resized server stays in VERIFY_RESIZE until the resize is confirmed or
+reverted. Pass --confirm together with --flavor to do both in one step, or
+run the command again later with only --confirm or --revert.`,
+	Example: `  otc ecs resize web-01 --flavor s3.xlarge.2 --wait
+  otc ecs resize web-01 --confirm
+  otc ecs resize web-01 --flavor s3.xlarge.2 --confirm --wait`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resizeArgs.Selector.Patterns = args
+		return printActionResults(ecs.ResizeServers(resizeArgs))
+	},
+}
+
+var resizeArgs = ecs.ResizeArgs{
+	ActionArgs: newServerActionArgs(),
+	Flavor:     "",
+	Confirm:    false,
+	Revert:     false,
+}
+
+func init() {
+	ecsCmd.AddCommand(resizeEcsCommand)
+
+	initFlagsServerAction(resizeEcsCommand, &resizeArgs.ActionArgs)
+	resizeEcsCommand.Flags().StringVar(&resizeArgs.Flavor, "flavor", resizeArgs.Flavor, "Name or ID of the target flavor")
+	resizeEcsCommand.Flags().BoolVar(&resizeArgs.Confirm, "confirm", resizeArgs.Confirm, "Confirm the resize")
+	resizeEcsCommand.Flags().BoolVar(&resizeArgs.Revert, "revert", resizeArgs.Revert, "Revert a pending resize")
+	resizeEcsCommand.MarkFlagsMutuallyExclusive("confirm", "revert")
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsShowCmd = &cobra.Command{
+	Use:   "show <name|id|pattern>",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "Show details of an ECS server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showSelector.Patterns = args
+		server, err := ecs.Show(showSelector, commonConfig)
+		if err != nil {
+			return err
+		}
+		return formats.PrintDetail(format, *server, serverDetailView())
+	},
+}
+
+var showSelector = ecs.ServerSelector{}
+
+func init() {
+	ecsCmd.AddCommand(ecsShowCmd)
+	initFlagsServerSelector(ecsShowCmd, &showSelector, false)
+	initFlagFormat(ecsShowCmd)
+}
+
+func serverDetailView() formats.DetailView[ecs.ServerDetails] {
+	return formats.DetailView[ecs.ServerDetails]{
+		Fields: []formats.Column[ecs.ServerDetails]{
+			formats.Col("ID", func(s ecs.ServerDetails) string {
+				return s.ID
+			}),
+			formats.Col("Name", func(s ecs.ServerDetails) string {
+				return s.Name
+			}),
+			formats.Col("Status", func(s ecs.ServerDetails) string {
+				return s.Status
+			}),
+			formats.Col("Description", func(s ecs.ServerDetails) string {
+				return s.Description
+			}),
+			formats.Col("Availability Zone", func(s ecs.ServerDetails) string {
+				return s.AvailabilityZone
+			}),
+			formats.Col("Flavor", func(s ecs.ServerDetails) string {
+				f := s.FlavorDetails
+				return fmt.Sprintf("%s (%s vCPU, %s MB RAM)", f.Name, f.Vcpus, f.RAM)
+			}),
+			formats.Col("Image", func(s ecs.ServerDetails) string {
+				if id := ecs.ImageID(s.Server); id != "" {
+					return fmt.Sprintf("%s (%s)", s.ImageName, id)
+				}
+				return s.ImageName
+			}),
+			formats.Col("Key Pair", func(s ecs.ServerDetails) string {
+				return s.KeyName
+			}),
+			formats.Col("Addresses", func(s ecs.ServerDetails) []string {
+				var lines []string
+				for _, a := range ecs.Addresses(s.Server) {
+					lines = append(lines, fmt.Sprintf("%s: %s (%s)", a.Network, a.Addr, a.Type))
+				}
+				return lines
+			}, formats.Lines[ecs.ServerDetails]()),
+			formats.Col("Security Groups", func(s ecs.ServerDetails) []string {
+				var names []string
+				for _, sg := range s.SecurityGroups {
+					if name, ok := sg["name"].(string); ok {
+						names = append(names, name)
+					}
+				}
+				return names
+			}, formats.Lines[ecs.ServerDetails]()),
+			formats.Col("Volumes", func(s ecs.ServerDetails) []string {
+				var ids []string
+				for _, v := range s.VolumesAttached {
+					ids = append(ids, v.ID)
+				}
+				return ids
+			}, formats.Lines[ecs.ServerDetails]()),
+			formats.Col("Metadata", func(s ecs.ServerDetails) map[string]string {
+				return s.Metadata
+			}, formats.KeyValues[ecs.ServerDetails]()),
+			formats.Col("Tags", func(s ecs.ServerDetails) map[string]string {
+				return s.Tags
+			}, formats.KeyValues[ecs.ServerDetails]()),
+			formats.Col("Created At", func(s ecs.ServerDetails) time.Time {
+				return s.Created
+			}, formats.Time[ecs.ServerDetails](time.RFC3339)),
+			formats.Col("Updated At", func(s ecs.ServerDetails) time.Time {
+				return s.Updated
+			}, formats.Time[ecs.ServerDetails](time.RFC3339)),
+			formats.Col("Fault", func(s ecs.ServerDetails) string {
+				return s.Fault.Message
+			}),
+		},
+	}
+}
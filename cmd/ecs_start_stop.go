@@ -8,24 +8,34 @@ import (
 
 // listCmd represents the list command
 var startEcsCommand = &cobra.Command{
-	Use:   "start <name>",
-	Args:  cobra.ExactArgs(1),
-	Short: "Start ECS server",
+	Use:   "start <name|id|pattern>...",
+	Short: "Start ECS servers",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return ecs.StartServer(args[0], ecsListArgs.CommonConfig)
+		startArgs.Selector.Patterns = args
+		return printActionResults(ecs.StartServers(startArgs))
 	},
 }
 
 var stopEcsCommand = &cobra.Command{
-	Use:   "stop <name>",
-	Args:  cobra.ExactArgs(1),
-	Short: "Stop ECS server",
+	Use:   "stop <name|id|pattern>...",
+	Short: "Stop ECS servers",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return ecs.StopServer(args[0], ecsListArgs.CommonConfig)
+		stopArgs.Selector.Patterns = args
+		return printActionResults(ecs.StopServers(stopArgs))
 	},
 }
 
+var startArgs = newServerActionArgs()
+var stopArgs = ecs.StopArgs{
+	ActionArgs: newServerActionArgs(),
+	Force:      false,
+}
+
 func init() {
 	ecsCmd.AddCommand(stopEcsCommand)
 	ecsCmd.AddCommand(startEcsCommand)
+
+	initFlagsServerAction(startEcsCommand, &startArgs)
+	initFlagsServerAction(stopEcsCommand, &stopArgs.ActionArgs)
+	stopEcsCommand.Flags().BoolVar(&stopArgs.Force, "force", stopArgs.Force, "Power off the servers instead of shutting them down gracefully")
 }
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage tags of an ECS server",
+}
+
+var ecsTagListCmd = &cobra.Command{
+	Use:   "list <name|id|pattern>",
+	Args:  cobra.ExactArgs(1),
+	Short: "List the tags of a server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tags, err := ecs.ListTags(ecs.ServerSelector{Patterns: args[:1]}, commonConfig)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, tags, keyValueTableView())
+	},
+}
+
+var ecsTagAddCmd = &cobra.Command{
+	Use:   "add <name|id|pattern> key=value...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Add tags to a server, replacing values of existing keys",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tags, err := parseKeyValues(args[1:])
+		if err != nil {
+			return err
+		}
+		return ecs.AddTags(ecs.ServerSelector{Patterns: args[:1]}, tags, commonConfig)
+	},
+}
+
+var ecsTagRemoveCmd = &cobra.Command{
+	Use:   "remove <name|id|pattern> key...",
+	Args:  cobra.MinimumNArgs(2),
+	Short: "Remove tags from a server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ecs.RemoveTags(ecs.ServerSelector{Patterns: args[:1]}, args[1:], commonConfig)
+	},
+}
+
+func init() {
+	ecsCmd.AddCommand(ecsTagCmd)
+	ecsTagCmd.AddCommand(ecsTagListCmd)
+	ecsTagCmd.AddCommand(ecsTagAddCmd)
+	ecsTagCmd.AddCommand(ecsTagRemoveCmd)
+
+	initFlagFormat(ecsTagListCmd)
+}
+
+// parseKeyValues parses key=value arguments.
+func parseKeyValues(args []string) (map[string]string, error) {
+	result := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid argument '%s', expected key=value", arg)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+func keyValueTableView() formats.View[ecs.KeyValue] {
+	return formats.View[ecs.KeyValue]{
+		Columns: []formats.Column[ecs.KeyValue]{
+			formats.Col("Key", func(kv ecs.KeyValue) string {
+				return kv.Key
+			}),
+			formats.Col("Value", func(kv ecs.KeyValue) string {
+				return kv.Value
+			}),
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"otc-cli/formats"
+	"otc-cli/services/ecs"
+	"otc-cli/services/evs"
+
+	"github.com/spf13/cobra"
+)
+
+var ecsVolumesCmd = &cobra.Command{
+	Use:   "volumes <name|id|pattern>",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "List the volumes attached to an ECS server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		volumesSelector.Patterns = args
+		volumeList, err := evs.ServerVolumes(volumesSelector, commonConfig)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, volumeList, serverVolumesTableView())
+	},
+}
+
+var volumesSelector = ecs.ServerSelector{}
+
+func init() {
+	ecsCmd.AddCommand(ecsVolumesCmd)
+
+	initFlagsServerSelector(ecsVolumesCmd, &volumesSelector, false)
+	initFlagFormat(ecsVolumesCmd)
+}
+
+func serverVolumesTableView() formats.View[evs.ServerVolume] {
+	return formats.View[evs.ServerVolume]{
+		Columns: []formats.Column[evs.ServerVolume]{
+			formats.Col("Device", func(v evs.ServerVolume) string {
+				return v.Device
+			}),
+			formats.Col("ID", func(v evs.ServerVolume) string {
+				return v.ID
+			}),
+			formats.Col("Name", func(v evs.ServerVolume) string {
+				return v.Name
+			}),
+			formats.Col("Status", func(v evs.ServerVolume) string {
+				return v.Status
+			}),
+			formats.Col("Size (GB)", func(v evs.ServerVolume) int {
+				return v.Size
+			}, formats.RightAlign[evs.ServerVolume]()),
+			formats.Col("Type", func(v evs.ServerVolume) string {
+				return v.VolumeType
+			}),
+			formats.Col("Bootable", func(v evs.ServerVolume) bool {
+				return v.Bootable == "true"
+			}, formats.BoolYesNo[evs.ServerVolume]()),
+		},
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"otc-cli/errs"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes by kind of error, so that scripts can tell them apart.
+const (
+	exitError              = 1
+	exitUsage              = 2
+	exitNotFound           = 3
+	exitAmbiguous          = 4
+	exitUnauthorized       = 5
+	exitExpiredCredentials = 6
+	exitAPIError           = 7
+)
+
+// errorReport is the error printed to stderr with --format json.
+type errorReport struct {
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	ExitCode   int    `json:"exitCode"`
+	StatusCode int    `json:"statusCode,omitempty"`
+	Code       string `json:"code,omitempty"`
+	RequestID  string `json:"requestId,omitempty"`
+}
+
+// usageError marks invalid flags, which cobra reports as plain errors.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+func flagError(cmd *cobra.Command, err error) error {
+	return &usageError{err: err}
+}
+
+// usageErrorf reports invalid flags or arguments found by a command itself.
+func usageErrorf(format string, a ...any) error {
+	return &usageError{err: fmt.Errorf(format, a...)}
+}
+
+// markUsageErrors makes cmd and its subcommands report invalid arguments,
+// missing required flags and unknown subcommands as usage errors. Cobra only
+// passes flag parsing errors through the flag error function. Commands that
+// merely group subcommands print their help when called without arguments
+// instead of ignoring unknown ones.
+func markUsageErrors(cmd *cobra.Command) {
+	validate := cmd.Args
+	if validate == nil && cmd.HasSubCommands() {
+		validate = subcommandArgs
+		if !cmd.Runnable() {
+			cmd.RunE = func(cmd *cobra.Command, args []string) error {
+				return cmd.Help()
+			}
+		}
+	}
+
+	// Arguments are validated right after parsing the flags, before cobra
+	// checks the required flags itself, so these are checked here as well.
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		if validate != nil {
+			if err := validate(cmd, args); err != nil {
+				return &usageError{err: err}
+			}
+		}
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return &usageError{err: err}
+		}
+		if err := cmd.ValidateFlagGroups(); err != nil {
+			return &usageError{err: err}
+		}
+		return nil
+	}
+
+	for _, sub := range cmd.Commands() {
+		markUsageErrors(sub)
+	}
+}
+
+// subcommandArgs rejects arguments of commands grouping subcommands, which
+// can only be misspelled subcommands.
+func subcommandArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("unknown command %q for %q", args[0], cmd.CommandPath())
+	if cmd.SuggestionsMinimumDistance <= 0 {
+		cmd.SuggestionsMinimumDistance = 2
+	}
+	if suggestions := cmd.SuggestionsFor(args[0]); len(suggestions) > 0 {
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(suggestions, " or "))
+	}
+	return errors.New(msg)
+}
+
+// classifyError determines the kind and exit code of an error. Errors typed
+// by the services take precedence over the status of the API response they
+// may wrap.
+func classifyError(err error) (string, int) {
+	var usage *usageError
+	switch {
+	case errors.As(err, &usage):
+		return "usage", exitUsage
+	case errors.Is(err, errs.ErrExpiredCredentials):
+		return "expired_credentials", exitExpiredCredentials
+	case errors.Is(err, errs.ErrUnauthorized):
+		return "unauthorized", exitUnauthorized
+	case errors.Is(err, errs.ErrAmbiguous):
+		return "ambiguous", exitAmbiguous
+	case errors.Is(err, errs.ErrNotFound):
+		return "not_found", exitNotFound
+	}
+
+	if apiErr := errs.AsAPIError(err); apiErr != nil {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return "unauthorized", exitUnauthorized
+		case http.StatusNotFound:
+			return "not_found", exitNotFound
+		default:
+			return "api_error", exitAPIError
+		}
+	}
+	return "error", exitError
+}
+
+// reportError prints the error to w, as JSON object when JSON output
+// was requested, and returns the exit code for it. The exit status of an
+// external command is passed on without printing anything.
+func reportError(w io.Writer, err error) int {
+	var exitStatus *errs.ExitStatusError
+	if errors.As(err, &exitStatus) {
+		return exitStatus.Code
+	}
+
+	kind, code := classifyError(err)
+	apiErr := errs.AsAPIError(err)
+
+	if format == "json" {
+		report := errorReport{
+			Kind:     kind,
+			Message:  err.Error(),
+			ExitCode: code,
+		}
+		if apiErr != nil {
+			report.StatusCode = apiErr.StatusCode
+			report.Code = apiErr.Code
+			report.RequestID = apiErr.RequestID
+		}
+
+		data, jsonErr := json.MarshalIndent(map[string]errorReport{"error": report}, "", "  ")
+		if jsonErr == nil {
+			fmt.Fprintln(w, string(data))
+			return code
+		}
+	}
+
+	fmt.Fprintf(w, "Error: %s\n", err)
+	if apiErr != nil && apiErr.RequestID != "" && !strings.Contains(err.Error(), apiErr.RequestID) {
+		fmt.Fprintf(w, "Request ID: %s\n", apiErr.RequestID)
+	}
+	return code
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"otc-cli/errs"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/spf13/cobra"
+)
+
+func apiError(status int, body string) error {
+	resp := golangsdk.ErrUnexpectedResponseCode{Actual: status, Body: []byte(body)}
+	switch status {
+	case 401:
+		return golangsdk.ErrDefault401{ErrUnexpectedResponseCode: resp}
+	case 403:
+		return golangsdk.ErrDefault403{ErrUnexpectedResponseCode: resp}
+	case 404:
+		return golangsdk.ErrDefault404{ErrUnexpectedResponseCode: resp}
+	case 500:
+		return golangsdk.ErrDefault500{ErrUnexpectedResponseCode: resp}
+	}
+	return resp
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind string
+		wantCode int
+	}{
+		{"plain", errors.New("boom"), "error", exitError},
+		{"usage", usageErrorf("--a requires --b"), "usage", exitUsage},
+		{"not found", fmt.Errorf("x: %w", &errs.NotFoundError{Resource: "server", Name: "web"}), "not_found", exitNotFound},
+		{"ambiguous", &errs.AmbiguousError{Resource: "server", Name: "web", Count: 2}, "ambiguous", exitAmbiguous},
+		{"unauthorized", &errs.UnauthorizedError{Err: apiError(401, "")}, "unauthorized", exitUnauthorized},
+		{"expired", fmt.Errorf("x: %w", &errs.ExpiredCredentialsError{Err: apiError(401, "")}), "expired_credentials", exitExpiredCredentials},
+		{"401", fmt.Errorf("x: %w", apiError(401, "")), "unauthorized", exitUnauthorized},
+		{"403", apiError(403, ""), "unauthorized", exitUnauthorized},
+		{"404", fmt.Errorf("x: %w", apiError(404, "")), "not_found", exitNotFound},
+		{"500", apiError(500, ""), "api_error", exitAPIError},
+		{"other status", apiError(409, ""), "api_error", exitAPIError},
+		{"typed wins over status", fmt.Errorf("%w: %w", &errs.AmbiguousError{Resource: "server", Name: "web", Count: 2}, apiError(404, "")), "ambiguous", exitAmbiguous},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, code := classifyError(tt.err)
+			if kind != tt.wantKind || code != tt.wantCode {
+				t.Errorf("classifyError() = %s, %d, want %s, %d", kind, code, tt.wantKind, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestReportErrorJSON(t *testing.T) {
+	defer func(previous string) { format = previous }(format)
+	format = "json"
+
+	var out bytes.Buffer
+	err := fmt.Errorf("failed to get server: %w", apiError(404, `{"error_code":"Ecs.0114","error_msg":"Instance not found","request_id":"req-1"}`))
+	if code := reportError(&out, err); code != exitNotFound {
+		t.Errorf("exit code = %d, want %d", code, exitNotFound)
+	}
+
+	var report map[string]errorReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	want := errorReport{
+		Kind:       "not_found",
+		Message:    err.Error(),
+		ExitCode:   exitNotFound,
+		StatusCode: 404,
+		Code:       "Ecs.0114",
+		RequestID:  "req-1",
+	}
+	if report["error"] != want {
+		t.Errorf("report = %+v, want %+v", report["error"], want)
+	}
+
+	out.Reset()
+	reportError(&out, errors.New("boom"))
+	if strings.Contains(out.String(), "statusCode") || !strings.Contains(out.String(), `"kind": "error"`) {
+		t.Errorf("report of a plain error = %s", out.String())
+	}
+}
+
+func TestReportErrorText(t *testing.T) {
+	defer func(previous string) { format = previous }(format)
+	format = "table"
+
+	var out bytes.Buffer
+	code := reportError(&out, fmt.Errorf("failed: %w", apiError(500, `{"error_msg":"internal","request_id":"req-2"}`)))
+	if code != exitAPIError {
+		t.Errorf("exit code = %d, want %d", code, exitAPIError)
+	}
+	if !strings.HasPrefix(out.String(), "Error: failed: ") || !strings.HasSuffix(out.String(), "Request ID: req-2\n") {
+		t.Errorf("output = %q", out.String())
+	}
+
+	out.Reset()
+	if code := reportError(&out, &errs.ExitStatusError{Command: "ssh", Code: 255}); code != 255 || out.Len() != 0 {
+		t.Errorf("exit status = %d with output %q, want 255 without output", code, out.String())
+	}
+}
+
+func TestMarkUsageErrors(t *testing.T) {
+	newTree := func() *cobra.Command {
+		root := &cobra.Command{Use: "otc", SilenceErrors: true, SilenceUsage: true}
+		group := &cobra.Command{Use: "ecs"}
+		show := &cobra.Command{Use: "show", Args: cobra.ExactArgs(1), RunE: func(*cobra.Command, []string) error { return nil }}
+		create := &cobra.Command{Use: "create", RunE: func(*cobra.Command, []string) error { return nil }}
+		create.Flags().String("file", "", "")
+		_ = create.MarkFlagRequired("file")
+		root.AddCommand(group)
+		group.AddCommand(show, create)
+		root.SetOut(&bytes.Buffer{})
+		markUsageErrors(root)
+		return root
+	}
+
+	for _, tt := range []struct {
+		args      []string
+		wantUsage bool
+	}{
+		{[]string{"ecs", "show", "web"}, false},
+		{[]string{"ecs"}, false},
+		{[]string{"ecs", "show"}, true},
+		{[]string{"ecs", "show", "a", "b"}, true},
+		{[]string{"ecs", "create"}, true},
+		{[]string{"ecs", "create", "--file", "spec.yaml"}, false},
+		{[]string{"ecs", "shwo"}, true},
+		{[]string{"bogus"}, true},
+	} {
+		root := newTree()
+		root.SetArgs(tt.args)
+		err := root.Execute()
+
+		var usage *usageError
+		if gotUsage := errors.As(err, &usage); gotUsage != tt.wantUsage || (!tt.wantUsage && err != nil) {
+			t.Errorf("%v: error = %v, want usage error %v", tt.args, err, tt.wantUsage)
+		}
+	}
+}
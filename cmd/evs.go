@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"otc-cli/formats"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/volumes"
+	"github.com/spf13/cobra"
+)
+
+// evsCmd represents the evs command
+var evsCmd = &cobra.Command{
+	Use:   "evs",
+	Short: "Elastic Volume Service (EVS) management",
+}
+
+func init() {
+	rootCmd.AddCommand(evsCmd)
+}
+
+func volumesTableView() formats.View[volumes.Volume] {
+	return formats.View[volumes.Volume]{
+		Columns: []formats.Column[volumes.Volume]{
+			formats.Col("ID", func(v volumes.Volume) string {
+				return v.ID
+			}),
+			formats.Col("Name", func(v volumes.Volume) string {
+				return v.Name
+			}),
+			formats.Col("Status", func(v volumes.Volume) string {
+				return v.Status
+			}),
+			formats.Col("Size (GB)", func(v volumes.Volume) int {
+				return v.Size
+			}, formats.RightAlign[volumes.Volume]()),
+			formats.Col("Type", func(v volumes.Volume) string {
+				return v.VolumeType
+			}),
+			formats.Col("Availability Zone", func(v volumes.Volume) string {
+				return v.AvailabilityZone
+			}),
+			formats.Col("Bootable", func(v volumes.Volume) bool {
+				return v.Bootable == "true"
+			}, formats.BoolYesNo[volumes.Volume]()),
+			formats.Col("Attached To", func(v volumes.Volume) []string {
+				var servers []string
+				for _, a := range v.Attachments {
+					servers = append(servers, a.ServerID)
+				}
+				return servers
+			}, formats.Lines[volumes.Volume]()),
+		},
+	}
+}
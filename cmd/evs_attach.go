@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/services/evs"
+
+	"github.com/spf13/cobra"
+)
+
+var evsAttachCmd = &cobra.Command{
+	Use:   "attach <volume> <server>",
+	Args:  cobra.ExactArgs(2),
+	Short: "Attach an EVS volume to an ECS server",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evsAttachArgs.Volume = args[0]
+		evsAttachArgs.Server.Patterns = args[1:]
+
+		device, err := evs.Attach(evsAttachArgs)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Volume %s attached as %s\n", args[0], device)
+		return nil
+	},
+}
+
+var evsDetachCmd = &cobra.Command{
+	Use:   "detach <volume> [server]",
+	Args:  cobra.RangeArgs(1, 2),
+	Short: "Detach an EVS volume from an ECS server",
+	Long: `Detach an EVS volume from an ECS server.
+
+The server only has to be given for volumes attached to several servers.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evsDetachArgs.Volume = args[0]
+		evsDetachArgs.Server.Patterns = args[1:]
+		return evs.Detach(evsDetachArgs)
+	},
+}
+
+var evsAttachArgs = evs.AttachArgs{
+	Device:       "",
+	Wait:         false,
+	Timeout:      5 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+var evsDetachArgs = evs.DetachArgs{
+	Wait:         false,
+	Timeout:      5 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	evsCmd.AddCommand(evsAttachCmd)
+	evsCmd.AddCommand(evsDetachCmd)
+
+	evsAttachCmd.Flags().StringVar(&evsAttachArgs.Device, "device", evsAttachArgs.Device, "Device name, e.g. /dev/vdb (default chosen by the cloud)")
+	evsAttachCmd.Flags().BoolVar(&evsAttachArgs.Wait, "wait", evsAttachArgs.Wait, "Wait until the volume is attached")
+	evsAttachCmd.Flags().DurationVar(&evsAttachArgs.Timeout, "timeout", evsAttachArgs.Timeout, "Maximum time to wait when --wait is set")
+
+	evsDetachCmd.Flags().BoolVar(&evsDetachArgs.Wait, "wait", evsDetachArgs.Wait, "Wait until the volume is detached")
+	evsDetachCmd.Flags().DurationVar(&evsDetachArgs.Timeout, "timeout", evsDetachArgs.Timeout, "Maximum time to wait when --wait is set")
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"otc-cli/services/evs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/volumes"
+	"github.com/spf13/cobra"
+)
+
+var evsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List EVS volumes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printList(cmd, func() ([]volumes.Volume, error) {
+			return evs.List(evsListArgs)
+		}, volumesTableView, watchSpec[volumes.Volume]{
+			Key:    func(v volumes.Volume) string { return v.ID },
+			Name:   func(v volumes.Volume) string { return v.Name },
+			Status: func(v volumes.Volume) string { return v.Status },
+		})
+	},
+}
+
+var evsListArgs = evs.ListArgs{
+	Filter:       "",
+	Status:       "",
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	evsCmd.AddCommand(evsListCmd)
+
+	evsListCmd.Flags().StringVar(&evsListArgs.Filter, "filter", evsListArgs.Filter, "Filter volumes by name")
+	evsListCmd.Flags().StringVar(&evsListArgs.Status, "status", evsListArgs.Status, "Only list volumes in the status, e.g. available or in-use")
+	initFlagFormat(evsListCmd)
+	initFlagsWatch(evsListCmd)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/evs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/volumes"
+	"github.com/spf13/cobra"
+)
+
+var evsCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Create an EVS volume",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evsCreateArgs.Name = args[0]
+		volume, err := evs.Create(evsCreateArgs)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, []volumes.Volume{*volume}, volumesTableView())
+	},
+}
+
+var evsDeleteCmd = &cobra.Command{
+	Use:   "delete <name|id>...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Delete EVS volumes",
+	Long: `Delete EVS volumes.
+
+The volumes have to be confirmed interactively unless --yes is given. Volumes
+that are still attached to a server are refused.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evsDeleteArgs.Volumes = args
+		evsDeleteArgs.Confirm = confirmVolumeDeletion
+
+		if err := evs.Delete(evsDeleteArgs); err != nil {
+			return err
+		}
+		fmt.Println("Volumes deleted")
+		return nil
+	},
+}
+
+var evsExtendCmd = &cobra.Command{
+	Use:   "extend <name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Extend an EVS volume",
+	Long: `Extend an EVS volume.
+
+Attached volumes are extended online; the partition and file system have to
+be grown inside the server afterwards.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		evsExtendArgs.Volume = args[0]
+		return evs.Extend(evsExtendArgs)
+	},
+}
+
+var evsCreateArgs = evs.CreateArgs{
+	Size:         0,
+	VolumeType:   "SSD",
+	Wait:         false,
+	Timeout:      5 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+var evsDeleteArgs = evs.DeleteArgs{
+	CommonConfig: commonConfig,
+}
+
+var evsDeleteYes bool
+
+var evsExtendArgs = evs.ExtendArgs{
+	Size:         0,
+	Wait:         false,
+	Timeout:      5 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	evsCmd.AddCommand(evsCreateCmd)
+	evsCmd.AddCommand(evsDeleteCmd)
+	evsCmd.AddCommand(evsExtendCmd)
+
+	evsCreateCmd.Flags().IntVar(&evsCreateArgs.Size, "size", evsCreateArgs.Size, "Size in GB")
+	evsCreateCmd.Flags().StringVar(&evsCreateArgs.VolumeType, "type", evsCreateArgs.VolumeType, "Volume type, e.g. SATA, SAS or SSD")
+	evsCreateCmd.Flags().StringVar(&evsCreateArgs.AvailabilityZone, "az", evsCreateArgs.AvailabilityZone, "Availability zone")
+	evsCreateCmd.Flags().StringVar(&evsCreateArgs.Description, "description", evsCreateArgs.Description, "Description")
+	evsCreateCmd.Flags().StringVar(&evsCreateArgs.SnapshotID, "snapshot", evsCreateArgs.SnapshotID, "ID of a snapshot to create the volume from")
+	evsCreateCmd.Flags().BoolVar(&evsCreateArgs.Wait, "wait", evsCreateArgs.Wait, "Wait until the volume is available")
+	evsCreateCmd.Flags().DurationVar(&evsCreateArgs.Timeout, "timeout", evsCreateArgs.Timeout, "Maximum time to wait when --wait is set")
+	initFlagFormat(evsCreateCmd)
+
+	evsDeleteCmd.Flags().BoolVarP(&evsDeleteYes, "yes", "y", evsDeleteYes, "Do not ask for confirmation")
+
+	evsExtendCmd.Flags().IntVar(&evsExtendArgs.Size, "size", evsExtendArgs.Size, "New size in GB")
+	evsExtendCmd.Flags().BoolVar(&evsExtendArgs.Wait, "wait", evsExtendArgs.Wait, "Wait until the volume is extended")
+	evsExtendCmd.Flags().DurationVar(&evsExtendArgs.Timeout, "timeout", evsExtendArgs.Timeout, "Maximum time to wait when --wait is set")
+	_ = evsExtendCmd.MarkFlagRequired("size")
+}
+
+func confirmVolumeDeletion(volumeList []volumes.Volume) (bool, error) {
+	fmt.Println("The following volumes will be deleted:")
+	if err := formats.PrintFormatted("table", volumeList, volumesTableView()); err != nil {
+		return false, err
+	}
+
+	if evsDeleteYes {
+		return true, nil
+	}
+	return confirm(fmt.Sprintf("Delete %d volumes?", len(volumeList)))
+}
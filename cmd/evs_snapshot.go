@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/evs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/snapshots"
+	"github.com/spf13/cobra"
+)
+
+var evsSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Manage EVS snapshots",
+}
+
+var evsSnapshotListCmd = &cobra.Command{
+	Use:   "list [volume]",
+	Args:  cobra.MaximumNArgs(1),
+	Short: "List snapshots, optionally of a single volume",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			snapshotListArgs.Volume = args[0]
+		}
+		return printList(cmd, func() ([]snapshots.Snapshot, error) {
+			return evs.ListSnapshots(snapshotListArgs)
+		}, snapshotsTableView, watchSpec[snapshots.Snapshot]{
+			Key:    func(s snapshots.Snapshot) string { return s.ID },
+			Name:   func(s snapshots.Snapshot) string { return s.Name },
+			Status: func(s snapshots.Snapshot) string { return s.Status },
+		})
+	},
+}
+
+var evsSnapshotCreateCmd = &cobra.Command{
+	Use:   "create <volume>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Create a snapshot of a volume",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotCreateArgs.Volume = args[0]
+		snapshot, err := evs.CreateSnapshot(snapshotCreateArgs)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, []snapshots.Snapshot{*snapshot}, snapshotsTableView())
+	},
+}
+
+var evsSnapshotDeleteCmd = &cobra.Command{
+	Use:   "delete <name|id>...",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "Delete snapshots",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotDeleteArgs.Snapshots = args
+		snapshotDeleteArgs.Confirm = confirmSnapshotDeletion
+
+		if err := evs.DeleteSnapshots(snapshotDeleteArgs); err != nil {
+			return err
+		}
+		fmt.Println("Snapshots deleted")
+		return nil
+	},
+}
+
+var evsSnapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Roll a volume back to a snapshot or create a new volume from it",
+	Long: `Roll a volume back to a snapshot or create a new volume from it.
+
+Rolling back overwrites the source volume, which has to be detached first.
+With --new-volume, a new volume is created from the snapshot instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotRestoreArgs.Snapshot = args[0]
+		volumeID, err := evs.RestoreSnapshot(snapshotRestoreArgs)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Snapshot %s restored to volume %s\n", args[0], volumeID)
+		return nil
+	},
+}
+
+var evsSnapshotPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete old snapshots, keeping the newest ones of every volume",
+	Long: `Delete old snapshots, keeping the newest ones of every volume.
+
+Snapshots older than --older-than are deleted, except for the --keep-last
+newest snapshots of each volume. --selector limits pruning to snapshots with
+the given metadata. The planned deletions are listed and have to be confirmed
+unless --yes is given.`,
+	Example: `  otc evs snapshot prune --older-than 14d --keep-last 3 --selector backup=daily`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pruneArgs.Confirm = confirmSnapshotDeletion
+
+		pruned, err := evs.PruneSnapshots(pruneArgs)
+		if err != nil {
+			return err
+		}
+		if len(pruned) == 0 {
+			fmt.Println("Nothing to prune")
+			return nil
+		}
+		fmt.Printf("Deleted %d snapshots\n", len(pruned))
+		return nil
+	},
+}
+
+var snapshotListArgs = evs.SnapshotListArgs{
+	CommonConfig: commonConfig,
+}
+
+var snapshotCreateArgs = evs.SnapshotCreateArgs{
+	Force:        false,
+	Wait:         false,
+	Timeout:      10 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+var snapshotDeleteArgs = evs.SnapshotDeleteArgs{
+	CommonConfig: commonConfig,
+}
+
+var snapshotRestoreArgs = evs.SnapshotRestoreArgs{
+	NewVolume:    "",
+	Wait:         false,
+	Timeout:      10 * time.Minute,
+	CommonConfig: commonConfig,
+}
+
+var pruneArgs = evs.PruneArgs{
+	OlderThan:    0,
+	KeepLast:     0,
+	CommonConfig: commonConfig,
+}
+
+var snapshotDeleteYes bool
+
+func init() {
+	evsCmd.AddCommand(evsSnapshotCmd)
+	evsSnapshotCmd.AddCommand(evsSnapshotListCmd)
+	evsSnapshotCmd.AddCommand(evsSnapshotCreateCmd)
+	evsSnapshotCmd.AddCommand(evsSnapshotDeleteCmd)
+	evsSnapshotCmd.AddCommand(evsSnapshotRestoreCmd)
+	evsSnapshotCmd.AddCommand(evsSnapshotPruneCmd)
+
+	evsSnapshotListCmd.Flags().StringToStringVar(&snapshotListArgs.Selector, "selector", snapshotListArgs.Selector, "Select snapshots by metadata (key=value), can be repeated")
+	initFlagFormat(evsSnapshotListCmd)
+	initFlagsWatch(evsSnapshotListCmd)
+
+	evsSnapshotCreateCmd.Flags().StringVar(&snapshotCreateArgs.Name, "name", snapshotCreateArgs.Name, "Snapshot name")
+	evsSnapshotCreateCmd.Flags().StringVar(&snapshotCreateArgs.Description, "description", snapshotCreateArgs.Description, "Snapshot description")
+	evsSnapshotCreateCmd.Flags().StringToStringVar(&snapshotCreateArgs.Metadata, "metadata", snapshotCreateArgs.Metadata, "Metadata (key=value) to set on the snapshot, can be repeated")
+	evsSnapshotCreateCmd.Flags().BoolVar(&snapshotCreateArgs.Force, "force", snapshotCreateArgs.Force, "Snapshot the volume even if it is attached")
+	evsSnapshotCreateCmd.Flags().BoolVar(&snapshotCreateArgs.Wait, "wait", snapshotCreateArgs.Wait, "Wait until the snapshot is available")
+	evsSnapshotCreateCmd.Flags().DurationVar(&snapshotCreateArgs.Timeout, "timeout", snapshotCreateArgs.Timeout, "Maximum time to wait when --wait is set")
+	initFlagFormat(evsSnapshotCreateCmd)
+
+	evsSnapshotDeleteCmd.Flags().BoolVarP(&snapshotDeleteYes, "yes", "y", snapshotDeleteYes, "Do not ask for confirmation")
+
+	evsSnapshotRestoreCmd.Flags().StringVar(&snapshotRestoreArgs.NewVolume, "new-volume", snapshotRestoreArgs.NewVolume, "Create a new volume with this name instead of rolling back")
+	evsSnapshotRestoreCmd.Flags().BoolVar(&snapshotRestoreArgs.Wait, "wait", snapshotRestoreArgs.Wait, "Wait until the volume is available")
+	evsSnapshotRestoreCmd.Flags().DurationVar(&snapshotRestoreArgs.Timeout, "timeout", snapshotRestoreArgs.Timeout, "Maximum time to wait when --wait is set")
+
+	evsSnapshotPruneCmd.Flags().StringVar(&pruneArgs.Volume, "volume", pruneArgs.Volume, "Only prune snapshots of the volume")
+	evsSnapshotPruneCmd.Flags().StringToStringVar(&pruneArgs.Selector, "selector", pruneArgs.Selector, "Only prune snapshots with the metadata (key=value), can be repeated")
+	dayDurationVar(evsSnapshotPruneCmd.Flags(), &pruneArgs.OlderThan, "older-than", "Minimum age of deleted snapshots, e.g. 14d or 36h")
+	evsSnapshotPruneCmd.Flags().IntVar(&pruneArgs.KeepLast, "keep-last", pruneArgs.KeepLast, "Number of newest snapshots to keep per volume")
+	evsSnapshotPruneCmd.Flags().BoolVarP(&snapshotDeleteYes, "yes", "y", snapshotDeleteYes, "Do not ask for confirmation")
+}
+
+func confirmSnapshotDeletion(snapshotList []snapshots.Snapshot) (bool, error) {
+	fmt.Println("The following snapshots will be deleted:")
+	if err := formats.PrintFormatted("table", snapshotList, snapshotsTableView()); err != nil {
+		return false, err
+	}
+
+	if snapshotDeleteYes {
+		return true, nil
+	}
+	return confirm(fmt.Sprintf("Delete %d snapshots?", len(snapshotList)))
+}
+
+func snapshotsTableView() formats.View[snapshots.Snapshot] {
+	return formats.View[snapshots.Snapshot]{
+		Columns: []formats.Column[snapshots.Snapshot]{
+			formats.Col("ID", func(s snapshots.Snapshot) string {
+				return s.ID
+			}),
+			formats.Col("Name", func(s snapshots.Snapshot) string {
+				return s.Name
+			}),
+			formats.Col("Volume", func(s snapshots.Snapshot) string {
+				return s.VolumeID
+			}),
+			formats.Col("Status", func(s snapshots.Snapshot) string {
+				return s.Status
+			}),
+			formats.Col("Size (GB)", func(s snapshots.Snapshot) int {
+				return s.Size
+			}, formats.RightAlign[snapshots.Snapshot]()),
+			formats.Col("Metadata", func(s snapshots.Snapshot) map[string]string {
+				return s.Metadata
+			}, formats.KeyValues[snapshots.Snapshot]()),
+			formats.Col("Created At", func(s snapshots.Snapshot) time.Time {
+				return s.CreatedAt
+			}, formats.Time[snapshots.Snapshot](time.RFC3339)),
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// imsCmd represents the ims command
+var imsCmd = &cobra.Command{
+	Use:   "ims",
+	Short: "Image Management Service (IMS) operations",
+}
+
+var imsImagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "Browse images",
+}
+
+func init() {
+	rootCmd.AddCommand(imsCmd)
+	imsCmd.AddCommand(imsImagesCmd)
+}
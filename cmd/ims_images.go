@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/ims"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ims/v2/images"
+	"github.com/spf13/cobra"
+)
+
+var imsImagesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List images available for ECS servers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		imageList, err := ims.List(imsImagesListArgs)
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, imageList, imagesTableView())
+	},
+}
+
+var imsImagesListArgs = ims.ListArgs{
+	Name:         "",
+	Visibility:   "",
+	OsType:       "",
+	Limit:        0,
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	imsImagesCmd.AddCommand(imsImagesListCmd)
+
+	imsImagesListCmd.Flags().StringVar(&imsImagesListArgs.Name, "filter", imsImagesListArgs.Name, "Filter images by name")
+	imsImagesListCmd.Flags().StringVar(&imsImagesListArgs.Visibility, "visibility", imsImagesListArgs.Visibility, "Only list public, private or shared images")
+	imsImagesListCmd.Flags().StringVar(&imsImagesListArgs.OsType, "os-type", imsImagesListArgs.OsType, "Only list Linux or Windows images")
+	imsImagesListCmd.Flags().IntVar(&imsImagesListArgs.Limit, "limit", imsImagesListArgs.Limit, "Limit the number of images listed")
+	initFlagFormat(imsImagesListCmd)
+}
+
+func imagesTableView() formats.View[images.ImageInfo] {
+	return formats.View[images.ImageInfo]{
+		Columns: []formats.Column[images.ImageInfo]{
+			formats.Col("ID", func(i images.ImageInfo) string {
+				return i.Id
+			}),
+			formats.Col("Name", func(i images.ImageInfo) string {
+				return i.Name
+			}),
+			formats.Col("OS", func(i images.ImageInfo) string {
+				return i.OsVersion
+			}),
+			formats.Col("Visibility", func(i images.ImageInfo) string {
+				return i.Visibility
+			}),
+			formats.Col("Min Disk (GB)", func(i images.ImageInfo) int {
+				return i.MinDisk
+			}, formats.RightAlign[images.ImageInfo]()),
+			formats.Col("Created At", func(i images.ImageInfo) time.Time {
+				return i.CreatedAt
+			}, formats.Time[images.ImageInfo](time.RFC3339)),
+		},
+	}
+}
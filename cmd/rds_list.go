@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	"otc-cli/formats"
 	"otc-cli/services/rds"
 
@@ -12,11 +16,13 @@ var rdsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List RDS instances",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		servers, err := rds.List(&rdsListArgs)
-		if err != nil {
-			return err
-		}
-		return formats.PrintFormatted(format, servers, rdsInstancesTableView())
+		return printList(cmd, func() ([]instances.InstanceResponse, error) {
+			return rds.List(&rdsListArgs)
+		}, rdsInstancesTableView, watchSpec[instances.InstanceResponse]{
+			Key:    func(i instances.InstanceResponse) string { return i.Id },
+			Name:   func(i instances.InstanceResponse) string { return i.Name },
+			Status: func(i instances.InstanceResponse) string { return i.Status },
+		})
 	},
 }
 
@@ -28,9 +34,11 @@ var rdsListArgs = rds.ListArgs{
 func init() {
 	rdsCmd.AddCommand(rdsListCmd)
 
-	rdsListCmd.Flags().StringVar(&rdsListArgs.Opts.Name, "filter", ecsListArgs.Filter, "Filter instances by name")
-	rdsListCmd.Flags().IntVar(&rdsListArgs.Opts.Limit, "limit", ecsListArgs.Limit, "Limit the number of instances listed")
+	rdsListCmd.Flags().StringVar(&rdsListArgs.Opts.Name, "filter", rdsListArgs.Opts.Name, "Filter instances by name")
+	rdsListCmd.Flags().IntVar(&rdsListArgs.Opts.Limit, "limit", rdsListArgs.Opts.Limit, "Limit the number of instances listed")
 	initFlagFormat(rdsListCmd)
+	initFlagWide(rdsListCmd)
+	initFlagsWatch(rdsListCmd)
 }
 
 func rdsInstancesTableView() formats.View[instances.InstanceResponse] {
@@ -51,6 +59,79 @@ func rdsInstancesTableView() formats.View[instances.InstanceResponse] {
 			formats.Col("Datastore Version", func(i instances.InstanceResponse) string {
 				return i.DataStore.Version
 			}),
+			formats.Col("Flavor", func(i instances.InstanceResponse) string {
+				return i.FlavorRef
+			}, formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("HA Mode", func(i instances.InstanceResponse) string {
+				return rdsHAMode(i)
+			}, formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Nodes", func(i instances.InstanceResponse) []string {
+				return rdsNodeRoles(i)
+			}, formats.Lines[instances.InstanceResponse](), formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Private IPs", func(i instances.InstanceResponse) []string {
+				return i.PrivateIps
+			}, formats.Lines[instances.InstanceResponse](), formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Public IPs", func(i instances.InstanceResponse) []string {
+				return i.PublicIps
+			}, formats.Lines[instances.InstanceResponse](), formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Port", func(i instances.InstanceResponse) string {
+				return strconv.Itoa(i.Port)
+			}, formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Storage", func(i instances.InstanceResponse) string {
+				return rdsStorage(i)
+			}, formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Backup Window", func(i instances.InstanceResponse) string {
+				return rdsBackupWindow(i)
+			}, formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Maintenance Window", func(i instances.InstanceResponse) string {
+				return i.MaintenanceWindow
+			}, formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Created At", func(i instances.InstanceResponse) time.Time {
+				return rds.Created(i)
+			}, formats.Time[instances.InstanceResponse](time.RFC3339), formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Region / AZ", func(i instances.InstanceResponse) []string {
+				return append([]string{i.Region}, rds.AvailabilityZones(i)...)
+			}, formats.Lines[instances.InstanceResponse](), formats.Wide[instances.InstanceResponse](wide)),
+			formats.Col("Tags", func(i instances.InstanceResponse) map[string]string {
+				return rds.Tags(i)
+			}, formats.KeyValues[instances.InstanceResponse](), formats.Wide[instances.InstanceResponse](wide)),
 		},
 	}
 }
+
+// rdsHAMode returns the instance type with the replication mode of
+// primary/standby instances, e.g. "Ha (semisync)".
+func rdsHAMode(i instances.InstanceResponse) string {
+	if i.Ha.ReplicationMode == "" {
+		return i.Type
+	}
+	return fmt.Sprintf("%s (%s)", i.Type, i.Ha.ReplicationMode)
+}
+
+// rdsNodeRoles returns the role and availability zone of every node.
+func rdsNodeRoles(i instances.InstanceResponse) []string {
+	var roles []string
+	for _, n := range i.Nodes {
+		roles = append(roles, fmt.Sprintf("%s: %s (%s)", n.Role, n.Name, n.AvailabilityZone))
+	}
+	return roles
+}
+
+func rdsStorage(i instances.InstanceResponse) string {
+	if i.Volume.Type == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s %d GB", i.Volume.Type, i.Volume.Size)
+}
+
+// rdsBackupWindow returns the automated backup window with the retention
+// period.
+func rdsBackupWindow(i instances.InstanceResponse) string {
+	if i.BackupStrategy.StartTime == "" {
+		return ""
+	}
+	if i.BackupStrategy.KeepDays == 0 {
+		return i.BackupStrategy.StartTime + " (disabled)"
+	}
+	return fmt.Sprintf("%s (%d days)", i.BackupStrategy.StartTime, i.BackupStrategy.KeepDays)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"otc-cli/formats"
+	"otc-cli/services/rds"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/rds/v3/instances"
+	"github.com/spf13/cobra"
+)
+
+var rdsShowCmd = &cobra.Command{
+	Use:   "show <instance-name|id>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Show details of an RDS instance",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		instance, err := rds.Show(args[0], commonConfig)
+		if err != nil {
+			return err
+		}
+		return formats.PrintDetail(format, *instance, rdsInstanceDetailView())
+	},
+}
+
+func init() {
+	rdsCmd.AddCommand(rdsShowCmd)
+	initFlagFormat(rdsShowCmd)
+}
+
+func rdsInstanceDetailView() formats.DetailView[instances.InstanceResponse] {
+	return formats.DetailView[instances.InstanceResponse]{
+		Fields: []formats.Column[instances.InstanceResponse]{
+			formats.Col("ID", func(i instances.InstanceResponse) string {
+				return i.Id
+			}),
+			formats.Col("Name", func(i instances.InstanceResponse) string {
+				return i.Name
+			}),
+			formats.Col("Alias", func(i instances.InstanceResponse) string {
+				return i.Alias
+			}),
+			formats.Col("Status", func(i instances.InstanceResponse) string {
+				return i.Status
+			}),
+			formats.Col("Datastore", func(i instances.InstanceResponse) string {
+				return i.DataStore.Type + " " + i.DataStore.Version
+			}),
+			formats.Col("Flavor", func(i instances.InstanceResponse) string {
+				return i.FlavorRef
+			}),
+			formats.Col("vCPUs", func(i instances.InstanceResponse) string {
+				return i.Cpu
+			}),
+			formats.Col("Memory (GB)", func(i instances.InstanceResponse) string {
+				return i.Mem
+			}),
+			formats.Col("HA Mode", func(i instances.InstanceResponse) string {
+				return rdsHAMode(i)
+			}),
+			formats.Col("Switch Strategy", func(i instances.InstanceResponse) string {
+				return i.SwitchStrategy
+			}),
+			formats.Col("Nodes", func(i instances.InstanceResponse) []string {
+				return rdsNodeRoles(i)
+			}, formats.Lines[instances.InstanceResponse]()),
+			formats.Col("Private IPs", func(i instances.InstanceResponse) []string {
+				return i.PrivateIps
+			}, formats.Lines[instances.InstanceResponse]()),
+			formats.Col("Public IPs", func(i instances.InstanceResponse) []string {
+				return i.PublicIps
+			}, formats.Lines[instances.InstanceResponse]()),
+			formats.Col("Port", func(i instances.InstanceResponse) string {
+				return strconv.Itoa(i.Port)
+			}),
+			formats.Col("DB User", func(i instances.InstanceResponse) string {
+				return i.DbUserName
+			}),
+			formats.Col("VPC", func(i instances.InstanceResponse) string {
+				return i.VpcId
+			}),
+			formats.Col("Subnet", func(i instances.InstanceResponse) string {
+				return i.SubnetId
+			}),
+			formats.Col("Security Group", func(i instances.InstanceResponse) string {
+				return i.SecurityGroupId
+			}),
+			formats.Col("Storage", func(i instances.InstanceResponse) string {
+				return rdsStorage(i)
+			}),
+			formats.Col("Backup Window", func(i instances.InstanceResponse) string {
+				return rdsBackupWindow(i)
+			}),
+			formats.Col("Maintenance Window", func(i instances.InstanceResponse) string {
+				return i.MaintenanceWindow
+			}),
+			formats.Col("Time Zone", func(i instances.InstanceResponse) string {
+				return i.TimeZone
+			}),
+			formats.Col("Region", func(i instances.InstanceResponse) string {
+				return i.Region
+			}),
+			formats.Col("Availability Zones", func(i instances.InstanceResponse) []string {
+				return rds.AvailabilityZones(i)
+			}, formats.Lines[instances.InstanceResponse]()),
+			formats.Col("Tags", func(i instances.InstanceResponse) map[string]string {
+				return rds.Tags(i)
+			}, formats.KeyValues[instances.InstanceResponse]()),
+			formats.Col("Created At", func(i instances.InstanceResponse) time.Time {
+				return rds.Created(i)
+			}, formats.Time[instances.InstanceResponse](time.RFC3339)),
+		},
+	}
+}
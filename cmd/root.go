@@ -12,11 +12,12 @@ var Version = "dev"
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
-	Use:          "otc",
-	Short:        "CLI tool for Open Telekom Cloud",
-	Long:         `otc is a command-line interface (CLI) tool designed to interact with Open Telekom Cloud services.`,
-	Version:      Version,
-	SilenceUsage: true,
+	Use:           "otc",
+	Short:         "CLI tool for Open Telekom Cloud",
+	Long:          `otc is a command-line interface (CLI) tool designed to interact with Open Telekom Cloud services.`,
+	Version:       Version,
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		return commonConfig.AugmentFromFiles()
 	},
@@ -27,13 +28,16 @@ var commonConfig = &config.CommonConfig{
 }
 
 var format string
+var wide bool
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Errors are printed to stderr and mapped to exit codes by their kind.
 func Execute() {
+	markUsageErrors(rootCmd)
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(reportError(os.Stderr, err))
 	}
 }
 
@@ -42,8 +46,13 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&commonConfig.CloudName, "cloud", "c", "", "Name of the cloud from clouds.yaml to use")
 	rootCmd.PersistentFlags().StringVarP(&commonConfig.Region, "region", "r", "", "Region to use for the cloud")
 	rootCmd.PersistentFlags().StringVarP(&commonConfig.ProjectName, "project", "p", "", "Project name to use for authentication")
+	rootCmd.SetFlagErrorFunc(flagError)
 }
 
 func initFlagFormat(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&format, "format", "table", "Output format: table, json, yaml")
 }
+
+func initFlagWide(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&wide, "wide", false, "Show additional columns in table output")
+}
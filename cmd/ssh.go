@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"otc-cli/config"
+	"otc-cli/services/ssh"
+
+	"github.com/spf13/cobra"
+)
+
+var sshCmd = &cobra.Command{
+	Use:   "ssh <name|id|pattern> [-- command...]",
+	Short: "Open an SSH session to an ECS server",
+	Long: `Open an SSH session to an ECS server.
+
+The server is resolved like in the ecs commands. The private key is looked up
+in the key directory by the name of the server's key pair (with or without a
+.pem extension). User, key directory, jump host and preferred address type
+default to the ssh section of the cloud in clouds.yaml.`,
+	Example: `  otc ssh web-01
+  otc ssh web-01 --user ubuntu -- uptime
+  otc ssh --selector role=db --jump-host admin@bastion.example.com`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			args = args[:dash]
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if cloud := commonConfig.SelectedCloud; cloud != nil {
+			config.SetIfEmpty(&sshArgs.User, cloud.SSH.User)
+			config.SetIfEmpty(&sshArgs.KeyDir, cloud.SSH.KeyDir)
+			config.SetIfEmpty(&sshArgs.JumpHost, cloud.SSH.JumpHost)
+			config.SetIfEmpty(&sshArgs.Address, cloud.SSH.Address)
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+			sshArgs.Command = args[dash:]
+			args = args[:dash]
+		}
+
+		sshArgs.Selector.Patterns = args
+		return ssh.Connect(sshArgs)
+	},
+}
+
+var sshArgs = ssh.Args{
+	User:         "",
+	KeyDir:       "",
+	JumpHost:     "",
+	Address:      "",
+	CommonConfig: commonConfig,
+}
+
+func init() {
+	rootCmd.AddCommand(sshCmd)
+
+	initFlagsServerSelector(sshCmd, &sshArgs.Selector, false)
+	sshCmd.Flags().StringVarP(&sshArgs.User, "user", "l", sshArgs.User, "Remote user")
+	sshCmd.Flags().StringVar(&sshArgs.KeyDir, "key-dir", sshArgs.KeyDir, "Directory with private keys named after key pairs (default ~/.ssh)")
+	sshCmd.Flags().StringVarP(&sshArgs.JumpHost, "jump-host", "J", sshArgs.JumpHost, "Jump host passed to ssh -J")
+	sshCmd.Flags().StringVar(&sshArgs.Address, "address", sshArgs.Address, "Address to connect to: floating or fixed")
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"otc-cli/formats"
+
+	"github.com/spf13/cobra"
+)
+
+var watch bool
+var watchInterval = 5 * time.Second
+
+func initFlagsWatch(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&watch, "watch", "w", watch, "Keep refreshing the list, highlighting status changes")
+	cmd.Flags().DurationVar(&watchInterval, "interval", watchInterval, "Refresh interval for --watch")
+}
+
+// watchSpec describes how list items are identified and compared between
+// refreshes.
+type watchSpec[T any] struct {
+	// Key identifies an item across refreshes.
+	Key func(T) string
+	// Name labels an item in change lines.
+	Name func(T) string
+	// Status is compared to highlight items whose status changed.
+	Status func(T) string
+}
+
+// printList prints the result of fetch once, or keeps refreshing it with
+// --watch. On a terminal the table is redrawn in place with changed rows
+// highlighted; otherwise the initial list is followed by one line per change.
+func printList[T any](cmd *cobra.Command, fetch func() ([]T, error), view func() formats.View[T], spec watchSpec[T]) error {
+	if !watch {
+		items, err := fetch()
+		if err != nil {
+			return err
+		}
+		return formats.PrintFormatted(format, items, view())
+	}
+
+	if format != "table" {
+		return errors.New("--watch only supports table output")
+	}
+	if watchInterval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+
+	// previous stays nil until the first list was drawn, so that a failed
+	// first poll does not turn the initial list into changes.
+	var previous map[string]T
+	var previousItems []T
+	for polled := false; ; polled = true {
+		if polled {
+			time.Sleep(watchInterval)
+		}
+
+		items, err := fetch()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format(time.TimeOnly), err)
+			continue
+		}
+
+		current := make(map[string]T, len(items))
+		for _, item := range items {
+			current[spec.Key(item)] = item
+		}
+
+		first := previous == nil
+		if isTerminal(os.Stdout) {
+			err = redrawList(cmd, items, view(), spec, previous, first)
+		} else {
+			err = printListChanges(items, view(), spec, previous, previousItems, first)
+		}
+		if err != nil {
+			return err
+		}
+		previous, previousItems = current, items
+	}
+}
+
+func redrawList[T any](cmd *cobra.Command, items []T, view formats.View[T], spec watchSpec[T], previous map[string]T, first bool) error {
+	view.Highlight = func(item T) bool {
+		if first {
+			return false
+		}
+		old, ok := previous[spec.Key(item)]
+		return !ok || spec.Status(old) != spec.Status(item)
+	}
+
+	// move the cursor home and clear the screen
+	fmt.Print("\033[H\033[2J")
+	fmt.Printf("Every %s: %s    %s\n\n", watchInterval, cmd.CommandPath(), time.Now().Format(time.TimeOnly))
+	return formats.PrintFormatted("table", items, view)
+}
+
+// printListChanges prints the initial list, and afterwards one line per added,
+// changed or removed item. Removed items are printed in their previous order.
+func printListChanges[T any](items []T, view formats.View[T], spec watchSpec[T], previous map[string]T, previousItems []T, first bool) error {
+	if first {
+		return formats.PrintFormatted("table", items, view)
+	}
+
+	now := time.Now().Format(time.RFC3339)
+	seen := map[string]bool{}
+	for _, item := range items {
+		key := spec.Key(item)
+		seen[key] = true
+
+		old, ok := previous[key]
+		if !ok {
+			_, values := view.Cells(item)
+			fmt.Printf("%s + %s\n", now, strings.Join(values, "  "))
+			continue
+		}
+
+		names, values := view.Cells(item)
+		_, oldValues := view.Cells(old)
+		var changes []string
+		for i := range values {
+			if values[i] != oldValues[i] {
+				changes = append(changes, fmt.Sprintf("%s: %s -> %s", names[i], oldValues[i], values[i]))
+			}
+		}
+		if len(changes) > 0 {
+			fmt.Printf("%s ~ %s  %s\n", now, spec.Name(item), strings.Join(changes, ", "))
+		}
+	}
+
+	for _, old := range previousItems {
+		if !seen[spec.Key(old)] {
+			fmt.Printf("%s - %s\n", now, spec.Name(old))
+		}
+	}
+	return nil
+}
+
+// isTerminal reports whether the file is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
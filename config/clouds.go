@@ -19,6 +19,8 @@ type CloudsYAML struct {
 type CloudConfig struct {
 	Auth        AuthConfig             `yaml:"auth"`
 	SSO         SSOConfig              `yaml:"sso,omitempty"`
+	ECS         ECSConfig              `yaml:"ecs,omitempty"`
+	SSH         SSHConfig              `yaml:"ssh,omitempty"`
 	RegionName  string                 `yaml:"region_name,omitempty"`
 	Cloud       string                 `yaml:"cloud,omitempty"`
 	Interface   string                 `yaml:"interface,omitempty"`
@@ -58,6 +60,31 @@ type SSOConfig struct {
 	Extra      map[string]interface{} `yaml:",inline"`
 }
 
+// ECSConfig holds per-cloud defaults for ECS commands
+type ECSConfig struct {
+	// ProtectionTag is the tag key marking servers that must not be deleted
+	ProtectionTag string `yaml:"protection_tag,omitempty"`
+	// FlavorPrices are hourly prices by flavor name, shown as hints when
+	// listing flavors since the flavor API does not report prices
+	FlavorPrices map[string]float64 `yaml:"flavor_prices,omitempty"`
+	// PriceCurrency is shown with the flavor prices, e.g. "EUR"
+	PriceCurrency string                 `yaml:"price_currency,omitempty"`
+	Extra         map[string]interface{} `yaml:",inline"`
+}
+
+// SSHConfig holds per-cloud defaults for the ssh command
+type SSHConfig struct {
+	// User is the remote login user
+	User string `yaml:"user,omitempty"`
+	// KeyDir is the directory holding private keys named after the key pairs
+	KeyDir string `yaml:"key_dir,omitempty"`
+	// JumpHost is passed to ssh -J, e.g. "user@bastion.example.com"
+	JumpHost string `yaml:"jump_host,omitempty"`
+	// Address selects the server IP to connect to: "floating" or "fixed"
+	Address string                 `yaml:"address,omitempty"`
+	Extra   map[string]interface{} `yaml:",inline"`
+}
+
 func LoadCloudsYAMLFromDefaultLocation() (CloudsYAML, error) {
 	cloudsPath, err := GetCloudsYAMLPath()
 	if err != nil {
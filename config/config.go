@@ -30,6 +30,25 @@ func (base *CommonConfig) AugmentFromFiles() error {
 	return nil
 }
 
+// ForCloud returns a configuration for another cloud of the same
+// clouds.yaml, using that cloud's region and project.
+func (base *CommonConfig) ForCloud(cloudName string) *CommonConfig {
+	derived := &CommonConfig{
+		EnvPrefix: base.EnvPrefix,
+		CloudName: cloudName,
+		Clouds:    base.Clouds,
+	}
+
+	if base.Clouds != nil {
+		if cloud, ok := base.Clouds.Clouds[cloudName]; ok {
+			derived.SelectedCloud = &cloud
+			derived.Region = cloud.RegionName
+			derived.ProjectName = cloud.Auth.ProjectName
+		}
+	}
+	return derived
+}
+
 func SetIfEmpty(value *string, newValues ...string) {
 	if *value == "" {
 		for _, v := range newValues {
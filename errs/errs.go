@@ -0,0 +1,244 @@
+// Package errs defines the errors commands are classified by, so that they
+// can be reported with distinct exit codes and as structured output.
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// Sentinels matched with errors.Is. The typed errors below match them, and
+// services may define their own error types matching them as well.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAmbiguous          = errors.New("ambiguous")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrExpiredCredentials = errors.New("expired credentials")
+)
+
+// NotFoundError is returned when a resource given by name or ID does not
+// exist.
+type NotFoundError struct {
+	Resource string
+	Name     string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s '%s' not found", e.Resource, e.Name)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// AmbiguousError is returned when a name matches several resources but only
+// one was expected.
+type AmbiguousError struct {
+	Resource string
+	Name     string
+	Count    int
+	// Hint tells how to pick a single resource, e.g. "use the volume ID".
+	Hint string
+}
+
+func (e *AmbiguousError) Error() string {
+	msg := fmt.Sprintf("%s name '%s' is ambiguous, %d %ss match", e.Resource, e.Name, e.Count, e.Resource)
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+	return msg
+}
+
+func (e *AmbiguousError) Is(target error) bool {
+	return target == ErrAmbiguous
+}
+
+// UnauthorizedError is returned when the cloud rejects the credentials.
+type UnauthorizedError struct {
+	Err error
+}
+
+func (e *UnauthorizedError) Error() string {
+	return fmt.Sprintf("credentials were rejected: %s", e.Err)
+}
+
+func (e *UnauthorizedError) Unwrap() error {
+	return e.Err
+}
+
+func (e *UnauthorizedError) Is(target error) bool {
+	return target == ErrUnauthorized
+}
+
+// ExpiredCredentialsError is returned when the temporary credentials stored
+// by "otc login" are rejected, which happens once they expired.
+type ExpiredCredentialsError struct {
+	Err error
+}
+
+func (e *ExpiredCredentialsError) Error() string {
+	return "temporary credentials have expired, run \"otc login\" to renew them"
+}
+
+func (e *ExpiredCredentialsError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ExpiredCredentialsError) Is(target error) bool {
+	return target == ErrExpiredCredentials
+}
+
+// ExitStatusError is returned when an external command the output of which
+// was passed through, like ssh, exited with a non-zero status. The command
+// already reported its failure, so only its status is passed on.
+type ExitStatusError struct {
+	Command string
+	Code    int
+}
+
+func (e *ExitStatusError) Error() string {
+	return fmt.Sprintf("%s exited with status %d", e.Command, e.Code)
+}
+
+// Result is the outcome of a command for one of several resources, which
+// can fail for some of them without failing the others.
+type Result interface {
+	Failed() bool
+}
+
+// FailedCount returns the number of failed results.
+func FailedCount[T Result](results []T) int {
+	failed := 0
+	for _, r := range results {
+		if r.Failed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// APIError describes an error response of the cloud.
+type APIError struct {
+	StatusCode int    `json:"statusCode"`
+	Code       string `json:"code,omitempty"`
+	Message    string `json:"message,omitempty"`
+	RequestID  string `json:"requestId,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Code != "" {
+		fmt.Fprintf(&b, "%s: ", e.Code)
+	}
+	if e.Message != "" {
+		b.WriteString(e.Message)
+	} else {
+		fmt.Fprintf(&b, "HTTP %d", e.StatusCode)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// AsAPIError returns the error response the SDK reported anywhere in the
+// chain of err, or nil when err did not come from an error response.
+func AsAPIError(err error) *APIError {
+	resp, ok := responseError(err)
+	if !ok {
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: resp.Actual}
+	parseErrorBody(resp.Body, apiErr)
+	return apiErr
+}
+
+// IsStatus reports whether err came from an error response with the given
+// HTTP status code.
+func IsStatus(err error, statusCode int) bool {
+	resp, ok := responseError(err)
+	return ok && resp.Actual == statusCode
+}
+
+// responseError finds the response the SDK rejected. The SDK wraps it in a
+// distinct type per status code, so each of them has to be tried.
+func responseError(err error) (golangsdk.ErrUnexpectedResponseCode, bool) {
+	var e400 golangsdk.ErrDefault400
+	var e401 golangsdk.ErrDefault401
+	var e403 golangsdk.ErrDefault403
+	var e404 golangsdk.ErrDefault404
+	var e405 golangsdk.ErrDefault405
+	var e408 golangsdk.ErrDefault408
+	var e409 golangsdk.ErrDefault409
+	var e429 golangsdk.ErrDefault429
+	var e500 golangsdk.ErrDefault500
+	var e503 golangsdk.ErrDefault503
+	var other golangsdk.ErrUnexpectedResponseCode
+
+	switch {
+	case errors.As(err, &e400):
+		return e400.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e401):
+		return e401.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e403):
+		return e403.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e404):
+		return e404.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e405):
+		return e405.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e408):
+		return e408.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e409):
+		return e409.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e429):
+		return e429.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e500):
+		return e500.ErrUnexpectedResponseCode, true
+	case errors.As(err, &e503):
+		return e503.ErrUnexpectedResponseCode, true
+	case errors.As(err, &other):
+		return other, true
+	}
+	return golangsdk.ErrUnexpectedResponseCode{}, false
+}
+
+// parseErrorBody extracts the error code, message and request ID from an
+// error response. OTC services disagree on the field names and on whether
+// they are nested in an "error" object, so the common variants are tried.
+func parseErrorBody(body []byte, apiErr *APIError) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		apiErr.Message = strings.TrimSpace(string(body))
+		return
+	}
+	if nested, ok := fields["error"].(map[string]interface{}); ok {
+		for k, v := range nested {
+			if _, exists := fields[k]; !exists {
+				fields[k] = v
+			}
+		}
+	}
+
+	apiErr.Code = firstString(fields, "error_code", "errorCode", "code")
+	apiErr.Message = firstString(fields, "error_msg", "errorMessage", "message", "reason", "error")
+	apiErr.RequestID = firstString(fields, "request_id", "requestId", "request-id")
+}
+
+func firstString(fields map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		switch v := fields[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return fmt.Sprintf("%.0f", v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+func response(status int, body string) golangsdk.ErrUnexpectedResponseCode {
+	return golangsdk.ErrUnexpectedResponseCode{
+		URL:      "https://ecs.eu-de.otc.t-systems.com/v1/servers",
+		Method:   http.MethodGet,
+		Expected: []int{200},
+		Actual:   status,
+		Body:     []byte(body),
+	}
+}
+
+func TestParseErrorBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want APIError
+	}{
+		{
+			name: "OTC style",
+			body: `{"error_code":"Ecs.0114","error_msg":"Instance not found","request_id":"req-1"}`,
+			want: APIError{Code: "Ecs.0114", Message: "Instance not found", RequestID: "req-1"},
+		},
+		{
+			name: "camel case",
+			body: `{"errorCode":"CCE.01404001","errorMessage":"Cluster not found","requestId":"req-2"}`,
+			want: APIError{Code: "CCE.01404001", Message: "Cluster not found", RequestID: "req-2"},
+		},
+		{
+			name: "nested error object",
+			body: `{"error":{"code":"APIGW.0301","message":"Incorrect IAM authentication information"},"request_id":"req-3"}`,
+			want: APIError{Code: "APIGW.0301", Message: "Incorrect IAM authentication information", RequestID: "req-3"},
+		},
+		{
+			name: "numeric code and reason",
+			body: `{"code":404,"reason":"itemNotFound"}`,
+			want: APIError{Code: "404", Message: "itemNotFound"},
+		},
+		{
+			name: "error as string",
+			body: `{"error":"invalid_token"}`,
+			want: APIError{Message: "invalid_token"},
+		},
+		{
+			name: "plain text",
+			body: "  502 Bad Gateway\n",
+			want: APIError{Message: "502 Bad Gateway"},
+		},
+		{
+			name: "empty",
+			want: APIError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got APIError
+			parseErrorBody([]byte(tt.body), &got)
+			if got != tt.want {
+				t.Errorf("parseErrorBody() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsAPIError(t *testing.T) {
+	body := `{"error_code":"X.1","error_msg":"failed","request_id":"req"}`
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"400", golangsdk.ErrDefault400{ErrUnexpectedResponseCode: response(400, body)}, 400},
+		{"401", golangsdk.ErrDefault401{ErrUnexpectedResponseCode: response(401, body)}, 401},
+		{"403", golangsdk.ErrDefault403{ErrUnexpectedResponseCode: response(403, body)}, 403},
+		{"404", golangsdk.ErrDefault404{ErrUnexpectedResponseCode: response(404, body)}, 404},
+		{"409", golangsdk.ErrDefault409{ErrUnexpectedResponseCode: response(409, body)}, 409},
+		{"500", golangsdk.ErrDefault500{ErrUnexpectedResponseCode: response(500, body)}, 500},
+		{"503", golangsdk.ErrDefault503{ErrUnexpectedResponseCode: response(503, body)}, 503},
+		{"other status", response(502, body), 502},
+		{"wrapped", fmt.Errorf("failed to get server: %w", golangsdk.ErrDefault404{ErrUnexpectedResponseCode: response(404, body)}), 404},
+		{"wrapped twice", fmt.Errorf("a: %w", fmt.Errorf("b: %w", response(429, body))), 429},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiErr := AsAPIError(tt.err)
+			if apiErr == nil {
+				t.Fatal("AsAPIError() = nil")
+			}
+			want := APIError{StatusCode: tt.status, Code: "X.1", Message: "failed", RequestID: "req"}
+			if *apiErr != want {
+				t.Errorf("AsAPIError() = %+v, want %+v", *apiErr, want)
+			}
+			if !IsStatus(tt.err, tt.status) || IsStatus(tt.err, tt.status+1) {
+				t.Errorf("IsStatus does not match only %d", tt.status)
+			}
+		})
+	}
+
+	if apiErr := AsAPIError(errors.New("connection refused")); apiErr != nil {
+		t.Errorf("AsAPIError() of a plain error = %+v, want nil", apiErr)
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	for _, tt := range []struct {
+		err  APIError
+		want string
+	}{
+		{APIError{StatusCode: 404, Code: "Ecs.0114", Message: "Instance not found", RequestID: "req"}, "Ecs.0114: Instance not found (request ID req)"},
+		{APIError{StatusCode: 502}, "HTTP 502"},
+	} {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	cause := golangsdk.ErrDefault401{ErrUnexpectedResponseCode: response(401, "")}
+	tests := []struct {
+		name   string
+		err    error
+		target error
+	}{
+		{"not found", fmt.Errorf("x: %w", &NotFoundError{Resource: "server", Name: "web"}), ErrNotFound},
+		{"ambiguous", &AmbiguousError{Resource: "volume", Name: "data", Count: 2}, ErrAmbiguous},
+		{"unauthorized", &UnauthorizedError{Err: cause}, ErrUnauthorized},
+		{"expired", &ExpiredCredentialsError{Err: cause}, ErrExpiredCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.target) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.target)
+			}
+			for _, other := range []error{ErrNotFound, ErrAmbiguous, ErrUnauthorized, ErrExpiredCredentials} {
+				if other != tt.target && errors.Is(tt.err, other) {
+					t.Errorf("%v also matches %v", tt.err, other)
+				}
+			}
+		})
+	}
+
+	if !IsStatus(&ExpiredCredentialsError{Err: cause}, 401) {
+		t.Error("ExpiredCredentialsError does not unwrap to the response")
+	}
+	if msg := (&AmbiguousError{Resource: "volume", Name: "data", Count: 2, Hint: "use the volume ID"}).Error(); msg != "volume name 'data' is ambiguous, 2 volumes match; use the volume ID" {
+		t.Errorf("AmbiguousError message = %q", msg)
+	}
+}
+
+type testResult struct {
+	err string
+}
+
+func (r testResult) Failed() bool {
+	return r.err != ""
+}
+
+func TestFailedCount(t *testing.T) {
+	results := []testResult{{}, {err: "boom"}, {}, {err: "timeout"}}
+	if got := FailedCount(results); got != 2 {
+		t.Errorf("FailedCount() = %d, want 2", got)
+	}
+	if got := FailedCount([]testResult(nil)); got != 0 {
+		t.Errorf("FailedCount(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,66 @@
+package formats
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jedib0t/go-pretty/table"
+	"gopkg.in/yaml.v2"
+)
+
+// DetailView describes how a single item is rendered as key/value pairs.
+// Fields reuse Column so the same options (Time, BoolYesNo, ...) apply.
+type DetailView[T any] struct {
+	Fields []Column[T]
+}
+
+type DetailRenderer[T any] interface {
+	RenderDetail(w io.Writer, view DetailView[T], item T) error
+}
+
+func (r *JsonRenderer[T]) RenderDetail(w io.Writer, view DetailView[T], item T) error {
+	json, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal item: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(json))
+	if err != nil {
+		return fmt.Errorf("unable to write JSON output: %w", err)
+	}
+	return nil
+}
+
+func (r *YamlRenderer[T]) RenderDetail(w io.Writer, view DetailView[T], item T) error {
+	yamlData, err := yaml.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("unable to marshal data to YAML: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(yamlData))
+	if err != nil {
+		return fmt.Errorf("unable to write YAML output: %w", err)
+	}
+	return nil
+}
+
+func (r PrettyTableRenderer[T]) RenderDetail(
+	w io.Writer,
+	view DetailView[T],
+	item T,
+) error {
+
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+
+	t.SetStyle(r.Style)
+
+	for _, f := range view.Fields {
+		if f.Hidden {
+			continue
+		}
+		t.AppendRow(table.Row{f.Name, f.Format(f.Value(item))})
+	}
+
+	t.Render()
+	return nil
+}
@@ -20,6 +20,19 @@ func newRenderer[T any](format string) (Renderer[T], error) {
 	}
 }
 
+func newDetailRenderer[T any](format string) (DetailRenderer[T], error) {
+	switch format {
+	case "json":
+		return &JsonRenderer[T]{}, nil
+	case "yaml":
+		return &YamlRenderer[T]{}, nil
+	default:
+		return &PrettyTableRenderer[T]{
+			Style: table.StyleLight,
+		}, nil
+	}
+}
+
 func PrintFormatted[T any](format string, data []T, view View[T]) error {
 	renderer, err := newRenderer[T](format)
 	if err != nil {
@@ -33,3 +46,17 @@ func PrintFormatted[T any](format string, data []T, view View[T]) error {
 
 	return nil
 }
+
+func PrintDetail[T any](format string, item T, view DetailView[T]) error {
+	renderer, err := newDetailRenderer[T](format)
+	if err != nil {
+		return fmt.Errorf("failed to create renderer: %w", err)
+	}
+
+	err = renderer.RenderDetail(os.Stdout, view, item)
+	if err != nil {
+		return fmt.Errorf("failed to render data: %w", err)
+	}
+
+	return nil
+}
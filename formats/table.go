@@ -3,6 +3,8 @@ package formats
 import (
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/jedib0t/go-pretty/table"
@@ -11,6 +13,20 @@ import (
 
 type View[T any] struct {
 	Columns []Column[T]
+	// Highlight marks rows to emphasize in table output.
+	Highlight func(T) bool
+}
+
+// Cells returns the names and formatted values of the visible columns.
+func (v View[T]) Cells(item T) (names []string, values []string) {
+	for _, c := range v.Columns {
+		if c.Hidden {
+			continue
+		}
+		names = append(names, c.Name)
+		values = append(values, c.Format(c.Value(item)))
+	}
+	return names, values
 }
 
 type Column[T any] struct {
@@ -70,6 +86,20 @@ func Time[T any](layout string) ColumnOption[T] {
 	}
 }
 
+// Wide hides the column unless wide output was requested.
+func Wide[T any](wide bool) ColumnOption[T] {
+	return func(c *Column[T]) {
+		c.Hidden = !wide
+	}
+}
+
+// Hidden hides the column when hidden is set, e.g. when it would be empty.
+func Hidden[T any](hidden bool) ColumnOption[T] {
+	return func(c *Column[T]) {
+		c.Hidden = hidden
+	}
+}
+
 func BoolYesNo[T any]() ColumnOption[T] {
 	return func(c *Column[T]) {
 		c.Format = func(v any) string {
@@ -84,6 +114,41 @@ func BoolYesNo[T any]() ColumnOption[T] {
 	}
 }
 
+// Lines renders a string slice with one entry per line.
+func Lines[T any]() ColumnOption[T] {
+	return func(c *Column[T]) {
+		c.Format = func(v any) string {
+			if l, ok := v.([]string); ok {
+				return strings.Join(l, "\n")
+			}
+			return ""
+		}
+	}
+}
+
+// KeyValues renders a string map as sorted key=value lines.
+func KeyValues[T any]() ColumnOption[T] {
+	return func(c *Column[T]) {
+		c.Format = func(v any) string {
+			m, ok := v.(map[string]string)
+			if !ok {
+				return ""
+			}
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			lines := make([]string, 0, len(keys))
+			for _, k := range keys {
+				lines = append(lines, k+"="+m[k])
+			}
+			return strings.Join(lines, "\n")
+		}
+	}
+}
+
 type Renderer[T any] interface {
 	Render(w io.Writer, view View[T], rows []T) error
 }
@@ -118,11 +183,17 @@ func (r PrettyTableRenderer[T]) Render(
 	t.AppendHeader(header)
 
 	// rows
+	highlight := text.Colors{text.Bold, text.FgYellow}
 	for _, row := range rows {
+		highlighted := view.Highlight != nil && view.Highlight(row)
+
 		r := table.Row{}
 		for _, col := range colMap {
-			v := col.Value(row)
-			r = append(r, col.Format(v))
+			cell := col.Format(col.Value(row))
+			if highlighted {
+				cell = highlight.Sprint(cell)
+			}
+			r = append(r, cell)
 		}
 		t.AppendRow(r)
 	}
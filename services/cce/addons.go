@@ -0,0 +1,165 @@
+package cce
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"otc-cli/config"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/addons"
+)
+
+// Addon is an add-on available for a cluster together with the version
+// installed in it, if any.
+type Addon struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Type is the template type, helm or static.
+	Type string `json:"type,omitempty"`
+	// Required add-ons are installed in every cluster by default.
+	Required         bool   `json:"required"`
+	InstalledVersion string `json:"installedVersion,omitempty"`
+	Status           string `json:"status,omitempty"`
+	// LatestVersion is the newest version supporting the cluster's version.
+	LatestVersion string `json:"latestVersion,omitempty"`
+	// TargetVersions are the versions the installed add-on can be upgraded to.
+	TargetVersions []string `json:"targetVersions,omitempty"`
+}
+
+// Installed reports whether the add-on is installed in the cluster.
+func (a Addon) Installed() bool {
+	return a.InstalledVersion != ""
+}
+
+// ListAddons returns the add-on templates available for a cluster merged
+// with the add-ons installed in it, sorted by name.
+func ListAddons(clusterName string, commonConfig *config.CommonConfig) ([]Addon, error) {
+	cce, err := getCCEClouds(commonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, instances, err := clusterAddons(cce, cluster.Metadata.Id)
+	if err != nil {
+		return nil, err
+	}
+	return mergeAddons(templates, instances, cluster.Spec.Type, cluster.Spec.Version), nil
+}
+
+// clusterAddons fetches the add-on templates and the installed add-on
+// instances of a cluster.
+func clusterAddons(cce *golangsdk.ServiceClient, clusterID string) ([]addons.AddonTemplate, []addons.Addon, error) {
+	templates, err := addons.ListTemplates(cce, clusterID, addons.ListOpts{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list add-on templates: %w", err)
+	}
+
+	instances, err := addons.ListAddonInstances(cce, clusterID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list add-ons: %w", err)
+	}
+	return templates.Items, instances.Items, nil
+}
+
+// mergeAddons combines templates and installed instances by template name.
+// Installed add-ons without a template are kept.
+func mergeAddons(templates []addons.AddonTemplate, instances []addons.Addon, clusterType, clusterVersion string) []Addon {
+	byName := map[string]*Addon{}
+	for _, t := range templates {
+		byName[t.Metadata.Name] = &Addon{
+			Name:          t.Metadata.Name,
+			Description:   t.Spec.Description,
+			Type:          t.Spec.Type,
+			Required:      t.Spec.Require,
+			LatestVersion: latestSupportedVersion(t, clusterType, clusterVersion),
+		}
+	}
+
+	for _, i := range instances {
+		addon, ok := byName[i.Spec.AddonTemplateName]
+		if !ok {
+			addon = &Addon{
+				Name:        i.Spec.AddonTemplateName,
+				Description: i.Spec.Description,
+				Type:        i.Spec.AddonTemplateType,
+			}
+			byName[addon.Name] = addon
+		}
+		addon.InstalledVersion = i.Spec.Version
+		addon.Status = i.Status.Status
+		addon.TargetVersions = i.Status.TargetVersions
+	}
+
+	result := make([]Addon, 0, len(byName))
+	for _, addon := range byName {
+		result = append(result, *addon)
+	}
+	slices.SortFunc(result, func(a, b Addon) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+// latestSupportedVersion returns the newest version of the template that
+// supports the given cluster type and version.
+func latestSupportedVersion(template addons.AddonTemplate, clusterType, clusterVersion string) string {
+	latest := ""
+	for _, v := range template.Spec.Versions {
+		if supportsCluster(v, clusterType, clusterVersion) && (latest == "" || compareVersions(v.Version, latest) > 0) {
+			latest = v.Version
+		}
+	}
+	return latest
+}
+
+// supportsCluster reports whether an add-on version supports a cluster. The
+// supported cluster versions are given as regular expressions.
+func supportsCluster(version addons.Version, clusterType, clusterVersion string) bool {
+	for _, s := range version.SupportVersions {
+		if !strings.EqualFold(s.ClusterType, clusterType) {
+			continue
+		}
+		for _, pattern := range s.ClusterVersion {
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if err == nil && re.MatchString(clusterVersion) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compareVersions compares versions like "v1.25.5-r0" or "1.8.7" segment by
+// segment, numerically where both segments are numbers.
+func compareVersions(a, b string) int {
+	split := func(v string) []string {
+		return strings.FieldsFunc(strings.TrimPrefix(v, "v"), func(r rune) bool {
+			return r == '.' || r == '-'
+		})
+	}
+
+	as, bs := split(a), split(b)
+	for i := range min(len(as), len(bs)) {
+		an, aErr := strconv.Atoi(strings.TrimPrefix(as[i], "r"))
+		bn, bErr := strconv.Atoi(strings.TrimPrefix(bs[i], "r"))
+		if aErr == nil && bErr == nil {
+			if an != bn {
+				return an - bn
+			}
+			continue
+		}
+		if c := strings.Compare(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return len(as) - len(bs)
+}
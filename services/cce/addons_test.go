@@ -0,0 +1,94 @@
+package cce
+
+import (
+	"testing"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/addons"
+)
+
+func addonVersion(version string, clusterVersions ...string) addons.Version {
+	return addons.Version{
+		Version: version,
+		SupportVersions: []addons.SupportVersion{{
+			ClusterType:    "VirtualMachine",
+			ClusterVersion: clusterVersions,
+		}},
+	}
+}
+
+func addonTemplate(name string, versions ...addons.Version) addons.AddonTemplate {
+	return addons.AddonTemplate{
+		Metadata: addons.MetaData{Name: name},
+		Spec:     addons.AddonTemplateSpec{Type: "helm", Versions: versions},
+	}
+}
+
+func addonInstance(name string, current addons.Version, targets ...string) addons.Addon {
+	return addons.Addon{
+		Spec:   addons.Spec{AddonTemplateName: name, Version: current.Version},
+		Status: addons.Status{Status: "running", CurrentVersion: current, TargetVersions: targets},
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.8.7", "1.10.0", -1},
+		{"v1.25.5-r0", "v1.25.5-r1", -1},
+		{"v1.27", "v1.25.5-r0", 1},
+		{"1.2.3", "1.2.3", 0},
+		{"1.2", "1.2.1", -1},
+	}
+	for _, tt := range tests {
+		got := compareVersions(tt.a, tt.b)
+		if (got < 0 && tt.want >= 0) || (got > 0 && tt.want <= 0) || (got == 0 && tt.want != 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, want sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMergeAddons(t *testing.T) {
+	templates := []addons.AddonTemplate{
+		addonTemplate("coredns", addonVersion("1.25.1", "v1.25.*"), addonVersion("1.28.4", "v1.(25|27).*"), addonVersion("1.30.0", "v1.29.*")),
+		addonTemplate("autoscaler", addonVersion("1.25.7", "v1.25.*")),
+	}
+	instances := []addons.Addon{
+		addonInstance("coredns", addonVersion("1.25.1", "v1.25.*"), "1.28.4"),
+		addonInstance("legacy", addonVersion("0.1.0")),
+	}
+
+	got := mergeAddons(templates, instances, "VirtualMachine", "v1.25")
+	if len(got) != 3 {
+		t.Fatalf("got %d add-ons, want 3: %+v", len(got), got)
+	}
+	if got[0].Name != "autoscaler" || got[0].Installed() || got[0].LatestVersion != "1.25.7" {
+		t.Errorf("unexpected autoscaler: %+v", got[0])
+	}
+	if got[1].Name != "coredns" || got[1].InstalledVersion != "1.25.1" || got[1].LatestVersion != "1.28.4" {
+		t.Errorf("unexpected coredns: %+v", got[1])
+	}
+	if got[2].Name != "legacy" || got[2].InstalledVersion != "0.1.0" {
+		t.Errorf("add-on without template not kept: %+v", got[2])
+	}
+}
+
+func TestAddonUpgrades(t *testing.T) {
+	templates := []addons.AddonTemplate{
+		addonTemplate("coredns", addonVersion("1.25.1", "v1.25.*"), addonVersion("1.28.4", "v1.(25|27).*")),
+		addonTemplate("everest", addonVersion("2.1.0", "v1.(25|27).*")),
+	}
+	instances := []addons.Addon{
+		addonInstance("coredns", addonVersion("1.25.1", "v1.25.*")),
+		addonInstance("everest", addonVersion("2.1.0", "v1.(25|27).*")),
+	}
+
+	got := addonUpgrades(templates, instances, "VirtualMachine", "v1.27.3-r0")
+	if len(got) != 1 {
+		t.Fatalf("got %d upgrades, want 1: %+v", len(got), got)
+	}
+	if got[0].Name != "coredns" || !got[0].Blocking || got[0].LatestVersion != "1.28.4" {
+		t.Errorf("unexpected upgrade: %+v", got[0])
+	}
+}
@@ -0,0 +1,213 @@
+package cce
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"otc-cli/config"
+	"otc-cli/errs"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
+)
+
+const (
+	BillingModeOnDemand = 0
+	BillingModeYearly   = 1
+
+	// creationTimestampLayout is the format of the creation timestamp in
+	// CCE responses, e.g. "2024-05-14 08:07:25.612421 +0000 UTC".
+	creationTimestampLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+)
+
+// Cluster is a CCE cluster with the details the SDK does not decode.
+type Cluster struct {
+	clusters.Clusters `yaml:",inline"`
+
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+	// Endpoints duplicates Status.Endpoints, which the SDK hides from JSON.
+	Endpoints []clusters.Endpoints `json:"endpoints,omitempty"`
+	// NodeCount is only set when requested, since it needs a request per
+	// cluster.
+	NodeCount *int `json:"nodeCount,omitempty"`
+}
+
+type ListArgs struct {
+	CommonConfig  *config.CommonConfig
+	WithNodeCount bool
+}
+
+func List(args ListArgs) ([]Cluster, error) {
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	clusterList, err := listClusters(cce)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.WithNodeCount {
+		if err := countNodes(cce, clusterList); err != nil {
+			return nil, err
+		}
+	}
+	return clusterList, nil
+}
+
+// Show returns the cluster with the given name or ID including its node
+// count.
+func Show(clusterName string, commonConfig *config.CommonConfig) (*Cluster, error) {
+	cce, err := getCCEClouds(commonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList := []Cluster{*cluster}
+	if err := countNodes(cce, clusterList); err != nil {
+		return nil, err
+	}
+	return &clusterList[0], nil
+}
+
+// listClusters lists the clusters of the project. The response is decoded
+// a second time for the creation timestamp, which clusters.Clusters lacks.
+func listClusters(cce *golangsdk.ServiceClient) ([]Cluster, error) {
+	resp, err := cce.Get(cce.ServiceURL("clusters"), nil, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster list: %w", err)
+	}
+
+	var list clusters.ListCluster
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster list: %w", err)
+	}
+	var metadata struct {
+		Items []struct {
+			Metadata struct {
+				CreationTimestamp string `json:"creationTimestamp"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster list: %w", err)
+	}
+
+	clusterList := make([]Cluster, len(list.Clusters))
+	for i, c := range list.Clusters {
+		clusterList[i] = Cluster{
+			Clusters:  c,
+			Endpoints: c.Status.Endpoints,
+		}
+		if i < len(metadata.Items) {
+			clusterList[i].CreationTimestamp = metadata.Items[i].Metadata.CreationTimestamp
+		}
+	}
+	return clusterList, nil
+}
+
+// findCluster returns the cluster with the given name or ID.
+func findCluster(cce *golangsdk.ServiceClient, nameOrID string) (*Cluster, error) {
+	clusterList, err := listClusters(cce)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range clusterList {
+		if c.Metadata.Id == nameOrID || c.Metadata.Name == nameOrID {
+			return &c, nil
+		}
+	}
+	return nil, &errs.NotFoundError{Resource: "cluster", Name: nameOrID}
+}
+
+// countNodes sets the node count of the clusters, querying them
+// concurrently.
+func countNodes(cce *golangsdk.ServiceClient, clusterList []Cluster) error {
+	errs := make([]error, len(clusterList))
+
+	var wg sync.WaitGroup
+	for i := range clusterList {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nodeList, err := nodes.List(cce, clusterList[i].Metadata.Id, nodes.ListOpts{})
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to list nodes of cluster %s: %w", clusterList[i].Metadata.Name, err)
+				return
+			}
+			count := len(nodeList)
+			clusterList[i].NodeCount = &count
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Created returns the creation time of the cluster, or the zero time when
+// it is missing or cannot be parsed.
+func (c Cluster) Created() time.Time {
+	created, err := time.Parse(creationTimestampLayout, c.CreationTimestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return created
+}
+
+// BillingMode returns a readable name of the cluster's billing mode.
+func (c Cluster) BillingMode() string {
+	switch c.Spec.BillingMode {
+	case BillingModeOnDemand:
+		return "on-demand"
+	case BillingModeYearly:
+		return "yearly/monthly"
+	default:
+		return fmt.Sprintf("%d", c.Spec.BillingMode)
+	}
+}
+
+// EndpointAddresses returns the API server addresses of the cluster, each
+// prefixed with its type.
+func (c Cluster) EndpointAddresses() []string {
+	var addresses []string
+	for _, e := range c.Endpoints {
+		if e.Url != "" {
+			addresses = append(addresses, fmt.Sprintf("%s: %s", e.Type, e.Url))
+		}
+		if e.Internal != "" {
+			addresses = append(addresses, fmt.Sprintf("internal: %s", e.Internal))
+		}
+		if e.External != "" {
+			addresses = append(addresses, fmt.Sprintf("external: %s", e.External))
+		}
+		if e.ExternalOTC != "" {
+			addresses = append(addresses, fmt.Sprintf("external_otc: %s", e.ExternalOTC))
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,139 @@
+package cce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+	"gopkg.in/yaml.v2"
+)
+
+type CreateArgs struct {
+	CommonConfig *config.CommonConfig
+	// SpecFile is a YAML file holding the cluster in the form of the CCE
+	// API, with kind, apiVersion, metadata and spec.
+	SpecFile string
+	// Name overrides the cluster name of the spec file when set.
+	Name    string
+	Wait    bool
+	Timeout time.Duration
+}
+
+// LoadCreateSpec reads a cluster spec from a YAML file. Fields are those of
+// the CCE cluster API; kind and apiVersion may be omitted.
+func LoadCreateSpec(path string) (clusters.CreateOpts, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return clusters.CreateOpts{}, fmt.Errorf("failed to read spec file: %w", err)
+	}
+	return parseCreateSpec(data)
+}
+
+// parseCreateSpec decodes the YAML spec through JSON, since the SDK types
+// only carry JSON tags. Unknown fields are rejected to catch typos.
+func parseCreateSpec(data []byte) (clusters.CreateOpts, error) {
+	var raw interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return clusters.CreateOpts{}, fmt.Errorf("failed to parse spec file: %w", err)
+	}
+
+	body, err := json.Marshal(jsonCompatible(raw))
+	if err != nil {
+		return clusters.CreateOpts{}, fmt.Errorf("failed to parse spec file: %w", err)
+	}
+
+	var opts clusters.CreateOpts
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&opts); err != nil {
+		return clusters.CreateOpts{}, fmt.Errorf("failed to parse spec file: %w", err)
+	}
+
+	config.SetIfEmpty(&opts.Kind, "Cluster")
+	config.SetIfEmpty(&opts.ApiVersion, "v3")
+	return opts, nil
+}
+
+// jsonCompatible converts the maps produced by the YAML decoder, which are
+// keyed by interface{}, into maps that can be encoded as JSON.
+func jsonCompatible(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = jsonCompatible(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonCompatible(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func validateCreateSpec(opts clusters.CreateOpts) error {
+	missing := []string{}
+	if opts.Metadata.Name == "" {
+		missing = append(missing, "metadata.name")
+	}
+	if opts.Spec.Flavor == "" {
+		missing = append(missing, "spec.flavor")
+	}
+	if opts.Spec.HostNetwork.VpcId == "" {
+		missing = append(missing, "spec.hostNetwork.vpc")
+	}
+	if opts.Spec.HostNetwork.SubnetId == "" {
+		missing = append(missing, "spec.hostNetwork.subnet")
+	}
+	if opts.Spec.ContainerNetwork.Mode == "" {
+		missing = append(missing, "spec.containerNetwork.mode")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required fields: %v", missing)
+	}
+	return nil
+}
+
+// Create creates a cluster from a spec file. With Wait it returns once the
+// cluster is available, which usually takes several minutes.
+func Create(args CreateArgs) (*clusters.Clusters, error) {
+	opts, err := LoadCreateSpec(args.SpecFile)
+	if err != nil {
+		return nil, err
+	}
+	config.SetIfEmpty(&args.Name, opts.Metadata.Name)
+	opts.Metadata.Name = args.Name
+	config.SetIfEmpty(&opts.Spec.Type, "VirtualMachine")
+
+	if err := validateCreateSpec(opts); err != nil {
+		return nil, err
+	}
+
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := clusters.Create(cce, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster %s: %w", opts.Metadata.Name, err)
+	}
+
+	if !args.Wait {
+		return cluster, nil
+	}
+
+	current, err := waitForClusterPhase(cce, cluster.Metadata.Id, ClusterPhaseAvailable, args.Timeout)
+	if err != nil {
+		return cluster, fmt.Errorf("failed waiting for cluster %s: %w", opts.Metadata.Name, err)
+	}
+	return current, nil
+}
@@ -0,0 +1,56 @@
+package cce
+
+import "testing"
+
+func TestParseCreateSpec(t *testing.T) {
+	opts, err := parseCreateSpec([]byte(`
+metadata:
+  name: dev
+  labels:
+    team: platform
+spec:
+  flavor: cce.s1.small
+  hostNetwork:
+    vpc: vpc-id
+    subnet: subnet-id
+  containerNetwork:
+    mode: vpc-router
+    cidr: 172.16.0.0/16
+  extendParam:
+    clusterAZ: eu-de-01
+`))
+	if err != nil {
+		t.Fatalf("parseCreateSpec: %v", err)
+	}
+
+	if opts.Kind != "Cluster" || opts.ApiVersion != "v3" {
+		t.Errorf("kind and apiVersion not defaulted: %q %q", opts.Kind, opts.ApiVersion)
+	}
+	if opts.Metadata.Name != "dev" || opts.Metadata.Labels["team"] != "platform" {
+		t.Errorf("metadata not parsed: %+v", opts.Metadata)
+	}
+	if opts.Spec.HostNetwork.VpcId != "vpc-id" || opts.Spec.HostNetwork.SubnetId != "subnet-id" {
+		t.Errorf("host network not parsed: %+v", opts.Spec.HostNetwork)
+	}
+	if opts.Spec.ContainerNetwork.Cidr != "172.16.0.0/16" {
+		t.Errorf("container network not parsed: %+v", opts.Spec.ContainerNetwork)
+	}
+	if opts.Spec.ExtendParam["clusterAZ"] != "eu-de-01" {
+		t.Errorf("extend params not parsed: %+v", opts.Spec.ExtendParam)
+	}
+	if err := validateCreateSpec(opts); err != nil {
+		t.Errorf("validateCreateSpec: %v", err)
+	}
+}
+
+func TestParseCreateSpecUnknownField(t *testing.T) {
+	_, err := parseCreateSpec([]byte(`
+metadata:
+  name: dev
+spec:
+  flavour: cce.s1.small
+`))
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
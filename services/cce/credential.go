@@ -0,0 +1,209 @@
+package cce
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+)
+
+const (
+	ExecCredentialAPIVersion = "client.authentication.k8s.io/v1"
+	ExecCredentialKind       = "ExecCredential"
+
+	// credentialDays is the validity of certificates fetched for the exec
+	// plugin, the shortest the certificate API accepts.
+	credentialDays = 1
+	// credentialRenewBefore makes kubectl ask for a new credential shortly
+	// before the certificate actually expires.
+	credentialRenewBefore = 5 * time.Minute
+)
+
+// ExecCredential is the response of a kubectl exec credential plugin.
+type ExecCredential struct {
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Status     ExecCredentialStatus `json:"status"`
+}
+
+type ExecCredentialStatus struct {
+	ExpirationTimestamp   time.Time `json:"expirationTimestamp"`
+	ClientCertificateData string    `json:"clientCertificateData"`
+	ClientKeyData         string    `json:"clientKeyData"`
+}
+
+type CredentialArgs struct {
+	CommonConfig *config.CommonConfig
+	ClusterName  string
+}
+
+// Credential returns a client certificate for the cluster as an exec
+// credential. Certificates are requested with the current session, cached
+// and reused until shortly before they expire.
+func Credential(args CredentialArgs) (*ExecCredential, error) {
+	cachePath, err := credentialCachePath(credentialCacheKey(args.CommonConfig, args.ClusterName))
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, err := loadCredential(cachePath); err != nil {
+		return nil, err
+	} else if cached != nil && time.Now().Before(cached.Status.ExpirationTimestamp) {
+		return cached, nil
+	}
+
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := clusters.GetCertWithExpiration(cce, cluster.Metadata.Id, clusters.ExpirationOpts{
+		Duration: credentialDays,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve cluster certificate: %w", err)
+	}
+
+	credential, err := execCredentialFromCertificate(cert)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveCredential(cachePath, credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// execCredentialFromCertificate extracts the client certificate of the
+// certificate's current context. Its expiry is read from the certificate
+// itself.
+func execCredentialFromCertificate(cert *clusters.Certificate) (*ExecCredential, error) {
+	kubeconfig, err := kubeconfigFromCertificate(cert, "credential", "")
+	if err != nil {
+		return nil, err
+	}
+	user := kubeconfig.Users[0].User
+
+	certPEM, err := base64.StdEncoding.DecodeString(user.ClientCertificateData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode client certificate: %w", err)
+	}
+	keyPEM, err := base64.StdEncoding.DecodeString(user.ClientKeyData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode client key: %w", err)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("client certificate is not PEM encoded")
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+
+	return &ExecCredential{
+		APIVersion: ExecCredentialAPIVersion,
+		Kind:       ExecCredentialKind,
+		Status: ExecCredentialStatus{
+			ExpirationTimestamp:   parsed.NotAfter.Add(-credentialRenewBefore).UTC(),
+			ClientCertificateData: string(certPEM),
+			ClientKeyData:         string(keyPEM),
+		},
+	}, nil
+}
+
+// ExecUser returns a kubeconfig user running this tool as exec credential
+// plugin for the cluster.
+func ExecUser(commonConfig *config.CommonConfig, clusterName string) KubeconfigUser {
+	args := []string{"cce", "credential", clusterName}
+	if commonConfig.CloudName != "" {
+		args = append(args, "--cloud", commonConfig.CloudName)
+	}
+	if commonConfig.Region != "" {
+		args = append(args, "--region", commonConfig.Region)
+	}
+	if commonConfig.ProjectName != "" {
+		args = append(args, "--project", commonConfig.ProjectName)
+	}
+
+	return KubeconfigUser{
+		Exec: &ExecConfig{
+			APIVersion:      ExecCredentialAPIVersion,
+			Command:         "otc",
+			Args:            args,
+			InteractiveMode: "Never",
+		},
+	}
+}
+
+// credentialCacheKey identifies the cached credential of a cluster. Cluster
+// names are only unique within a project, so the key includes the region
+// and project as given to the plugin, next to the cloud. Each part is
+// escaped so that the key is a single file name.
+func credentialCacheKey(commonConfig *config.CommonConfig, clusterName string) string {
+	parts := []string{commonConfig.CloudName, commonConfig.Region, commonConfig.ProjectName, clusterName}
+	for i, part := range parts {
+		parts[i] = url.QueryEscape(part)
+	}
+	return strings.Join(parts, ",")
+}
+
+func credentialCachePath(name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".otc-cli", "cce-credentials", name+".json"), nil
+}
+
+// loadCredential reads a cached credential. A missing or corrupt cache entry
+// yields nil so that a new credential is fetched and overwrites it.
+func loadCredential(path string) (*ExecCredential, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached credential %s: %w", path, err)
+	}
+
+	var credential ExecCredential
+	if err := json.Unmarshal(data, &credential); err != nil {
+		return nil, nil
+	}
+	return &credential, nil
+}
+
+func saveCredential(path string, credential *ExecCredential) error {
+	data, err := json.Marshal(credential)
+	if err != nil {
+		return fmt.Errorf("unable to marshal credential: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create credential cache directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to cache credential: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package cce
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"otc-cli/config"
+)
+
+func TestExecCredentialFromCertificate(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second).UTC()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user"},
+		NotBefore:    time.Now(),
+		NotAfter:     notAfter,
+	}, &x509.Certificate{SerialNumber: big.NewInt(1)}, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	cert := testCertificate()
+	cert.Users[0].User.ClientCertData = base64.StdEncoding.EncodeToString(certPEM)
+	cert.Users[0].User.ClientKeyData = base64.StdEncoding.EncodeToString(keyPEM)
+
+	credential, err := execCredentialFromCertificate(cert)
+	if err != nil {
+		t.Fatalf("execCredentialFromCertificate: %v", err)
+	}
+
+	if credential.APIVersion != ExecCredentialAPIVersion || credential.Kind != ExecCredentialKind {
+		t.Errorf("got %s %s", credential.APIVersion, credential.Kind)
+	}
+	if credential.Status.ClientCertificateData != string(certPEM) || credential.Status.ClientKeyData != string(keyPEM) {
+		t.Error("credential does not contain the decoded PEM data")
+	}
+	if want := notAfter.Add(-credentialRenewBefore); !credential.Status.ExpirationTimestamp.Equal(want) {
+		t.Errorf("expiration = %s, want %s", credential.Status.ExpirationTimestamp, want)
+	}
+}
+
+func TestExecUser(t *testing.T) {
+	user := ExecUser(&config.CommonConfig{CloudName: "prod", Region: "eu-de"}, "app")
+
+	want := []string{"cce", "credential", "app", "--cloud", "prod", "--region", "eu-de"}
+	if user.Exec == nil || user.Exec.APIVersion != ExecCredentialAPIVersion || user.Exec.Command != "otc" {
+		t.Fatalf("unexpected exec config: %+v", user.Exec)
+	}
+	if len(user.Exec.Args) != len(want) {
+		t.Fatalf("args = %v, want %v", user.Exec.Args, want)
+	}
+	for i := range want {
+		if user.Exec.Args[i] != want[i] {
+			t.Errorf("args = %v, want %v", user.Exec.Args, want)
+			break
+		}
+	}
+	if user.ClientCertificateData != "" || user.ClientKeyData != "" {
+		t.Error("exec user must not embed a certificate")
+	}
+}
+
+func TestCredentialCacheKey(t *testing.T) {
+	keys := map[string]string{}
+	for name, commonConfig := range map[string]*config.CommonConfig{
+		"default":       {CloudName: "prod"},
+		"other region":  {CloudName: "prod", Region: "eu-nl"},
+		"other project": {CloudName: "prod", ProjectName: "eu-de_team"},
+		"other cloud":   {CloudName: "dev"},
+		"separators":    {CloudName: "prod,eu-nl"},
+	} {
+		key := credentialCacheKey(commonConfig, "app")
+		if other, ok := keys[key]; ok {
+			t.Errorf("%s and %s share the cache key %q", name, other, key)
+		}
+		if filepath.Base(key) != key {
+			t.Errorf("cache key %q of %s is not a file name", key, name)
+		}
+		keys[key] = name
+	}
+
+	if a, b := credentialCacheKey(&config.CommonConfig{CloudName: "prod"}, "app"), credentialCacheKey(&config.CommonConfig{CloudName: "prod"}, "web"); a == b {
+		t.Errorf("clusters app and web share the cache key %q", a)
+	}
+}
+
+func TestLoadCredential(t *testing.T) {
+	dir := t.TempDir()
+
+	if credential, err := loadCredential(filepath.Join(dir, "missing.json")); credential != nil || err != nil {
+		t.Errorf("missing entry = %v, %v, want nil, nil", credential, err)
+	}
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if credential, err := loadCredential(corrupt); credential != nil || err != nil {
+		t.Errorf("corrupt entry = %v, %v, want nil, nil so that it is refetched", credential, err)
+	}
+
+	path := filepath.Join(dir, "sub", "valid.json")
+	want := &ExecCredential{
+		APIVersion: ExecCredentialAPIVersion,
+		Kind:       ExecCredentialKind,
+		Status: ExecCredentialStatus{
+			ExpirationTimestamp: time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC),
+			ClientKeyData:       "key",
+		},
+	}
+	if err := saveCredential(path, want); err != nil {
+		t.Fatalf("saveCredential: %v", err)
+	}
+	got, err := loadCredential(path)
+	if err != nil || got == nil {
+		t.Fatalf("loadCredential = %v, %v", got, err)
+	}
+	if !got.Status.ExpirationTimestamp.Equal(want.Status.ExpirationTimestamp) || got.Status.ClientKeyData != "key" {
+		t.Errorf("loadCredential = %+v, want %+v", got, want)
+	}
+}
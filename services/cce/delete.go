@@ -0,0 +1,91 @@
+package cce
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"otc-cli/config"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+)
+
+type DeleteArgs struct {
+	CommonConfig *config.CommonConfig
+	ClusterName  string
+	// DeleteEVS also deletes the EVS disks created for persistent volumes.
+	DeleteEVS bool
+	// DeleteELB also deletes the load balancers and other network resources
+	// created for services and ingresses.
+	DeleteELB bool
+	Wait      bool
+	Timeout   time.Duration
+	// Confirm is called with the cluster about to be deleted. Deletion only
+	// proceeds when it returns true.
+	Confirm func(cluster Cluster) (bool, error)
+}
+
+// Delete deletes a cluster together with its nodes. Resources created from
+// within the cluster are kept unless requested otherwise.
+func Delete(args DeleteArgs) error {
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if args.Confirm != nil {
+		ok, err := args.Confirm(*cluster)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrDeleteAborted
+		}
+	}
+
+	params := clusters.DeleteQueryParams{}
+	if args.DeleteEVS {
+		params.DeleteEvs = "true"
+	}
+	if args.DeleteELB {
+		params.DeleteNet = "true"
+	}
+
+	if err := clusters.Delete(cce, cluster.Metadata.Id, params); err != nil {
+		return fmt.Errorf("failed to delete cluster: %w", err)
+	}
+
+	if args.Wait {
+		return waitForClusterDeletion(cce, cluster.Metadata.Id, args.Timeout)
+	}
+	return nil
+}
+
+// waitForClusterDeletion polls until the cluster can no longer be found.
+func waitForClusterDeletion(cce *golangsdk.ServiceClient, id string, timeout time.Duration) error {
+	err := golangsdk.WaitFor(int(timeout.Seconds()), func() (bool, error) {
+		cluster, err := clusters.Get(cce, id)
+		if err == nil {
+			if cluster.Status.Phase == ClusterPhaseError {
+				return false, fmt.Errorf("cluster entered phase %s: %s", ClusterPhaseError, cluster.Status.Reason)
+			}
+			return false, nil
+		}
+
+		var notFound golangsdk.ErrDefault404
+		if errors.As(err, &notFound) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for cluster %s to be deleted: %w", id, err)
+	}
+	return nil
+}
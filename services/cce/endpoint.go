@@ -0,0 +1,53 @@
+package cce
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+)
+
+const (
+	// EndpointInternal is the API server address inside the cluster's VPC.
+	EndpointInternal = "internal"
+	// EndpointExternal is the API server address on the cluster's EIP.
+	EndpointExternal = "external"
+
+	// certificateContextExternalTLSVerify is the context of the external
+	// endpoint that verifies the server certificate. CCE only returns it
+	// when the certificate covers the EIP.
+	certificateContextExternalTLSVerify = "externalTLSVerify"
+)
+
+// endpointContext returns the context of the certificate pointing at the
+// endpoint. The external endpoint prefers the context verifying TLS. An
+// empty endpoint selects the certificate's current context.
+func endpointContext(cert *clusters.Certificate, endpoint string) (string, error) {
+	var candidates []string
+	switch endpoint {
+	case "":
+		return cert.CurrentContext, nil
+	case EndpointInternal:
+		candidates = []string{EndpointInternal}
+	case EndpointExternal:
+		candidates = []string{certificateContextExternalTLSVerify, EndpointExternal}
+	default:
+		return "", fmt.Errorf("unknown endpoint '%s', expected %s or %s", endpoint, EndpointInternal, EndpointExternal)
+	}
+
+	for _, candidate := range candidates {
+		for _, c := range cert.Contexts {
+			if c.Name == candidate {
+				return c.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("cluster has no %s endpoint", endpoint)
+}
+
+// expiryDays converts a certificate validity into the whole days the
+// certificate API expects, rounding up.
+func expiryDays(expiry time.Duration) int {
+	day := 24 * time.Hour
+	return int((expiry + day - 1) / day)
+}
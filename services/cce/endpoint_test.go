@@ -0,0 +1,56 @@
+package cce
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+)
+
+func TestEndpointContext(t *testing.T) {
+	withTLSVerify := testCertificate()
+	withTLSVerify.Contexts = append(withTLSVerify.Contexts, clusters.CertContexts{
+		Name:    "externalTLSVerify",
+		Context: clusters.CertContext{Cluster: "externalClusterTLSVerify", User: "user"},
+	})
+
+	tests := []struct {
+		name     string
+		cert     *clusters.Certificate
+		endpoint string
+		want     string
+	}{
+		{"default", testCertificate(), "", "internal"},
+		{"internal", testCertificate(), EndpointInternal, "internal"},
+		{"external", testCertificate(), EndpointExternal, "external"},
+		{"external with TLS verification", withTLSVerify, EndpointExternal, "externalTLSVerify"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := endpointContext(tt.cert, tt.endpoint)
+			if err != nil {
+				t.Fatalf("endpointContext: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := endpointContext(testCertificate(), "public"); err == nil {
+		t.Error("expected an error for an unknown endpoint")
+	}
+}
+
+func TestExpiryDays(t *testing.T) {
+	for expiry, want := range map[time.Duration]int{
+		24 * time.Hour:     1,
+		7 * 24 * time.Hour: 7,
+		36 * time.Hour:     2,
+		time.Hour:          1,
+	} {
+		if got := expiryDays(expiry); got != want {
+			t.Errorf("expiryDays(%s) = %d, want %d", expiry, got, want)
+		}
+	}
+}
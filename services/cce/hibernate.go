@@ -0,0 +1,137 @@
+package cce
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"otc-cli/config"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+)
+
+const (
+	ClusterPhaseAvailable   = "Available"
+	ClusterPhaseHibernation = "Hibernation"
+	ClusterPhaseError       = "Error"
+)
+
+type ClusterActionArgs struct {
+	CommonConfig *config.CommonConfig
+	ClusterNames []string
+	Wait         bool
+	Timeout      time.Duration
+}
+
+// ClusterActionResult is the outcome of an action performed on a single
+// cluster.
+type ClusterActionResult struct {
+	ID    string `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Phase string `json:"phase,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// HibernateClusters stops the master and worker nodes of the clusters.
+// Clusters that are already hibernated are left alone.
+func HibernateClusters(args ClusterActionArgs) ([]ClusterActionResult, error) {
+	return runClusterAction(args, "hibernate", ClusterPhaseHibernation)
+}
+
+// AwakeClusters starts hibernated clusters again. Clusters that are already
+// available are left alone.
+func AwakeClusters(args ClusterActionArgs) ([]ClusterActionResult, error) {
+	return runClusterAction(args, "awake", ClusterPhaseAvailable)
+}
+
+// runClusterAction resolves all clusters first, so that a misspelled name
+// fails before any cluster is touched, then applies the operation to the
+// clusters concurrently.
+func runClusterAction(args ClusterActionArgs, operation string, target string) ([]ClusterActionResult, error) {
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	clusterList := make([]*Cluster, len(args.ClusterNames))
+	for i, name := range args.ClusterNames {
+		clusterList[i], err = findCluster(cce, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]ClusterActionResult, len(clusterList))
+
+	var wg sync.WaitGroup
+	for i, cluster := range clusterList {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = applyClusterAction(cce, cluster, operation, target, args)
+		}()
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func applyClusterAction(cce *golangsdk.ServiceClient, cluster *Cluster, operation string, target string, args ClusterActionArgs) ClusterActionResult {
+	result := ClusterActionResult{
+		ID:    cluster.Metadata.Id,
+		Name:  cluster.Metadata.Name,
+		Phase: cluster.Status.Phase,
+	}
+
+	if cluster.Status.Phase != target {
+		_, err := cce.Post(cce.ServiceURL("clusters", cluster.Metadata.Id, "operation", operation), map[string]interface{}{}, nil, &golangsdk.RequestOpts{
+			OkCodes: []int{200},
+		})
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to %s cluster: %s", operation, err)
+			return result
+		}
+	}
+
+	if !args.Wait {
+		return result
+	}
+
+	current, err := waitForClusterPhase(cce, cluster.Metadata.Id, target, args.Timeout)
+	if current != nil {
+		result.Phase = current.Status.Phase
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// waitForClusterPhase polls the cluster until it reaches the given phase,
+// enters the error phase or the timeout expires. It returns the last seen
+// state of the cluster.
+func waitForClusterPhase(cce *golangsdk.ServiceClient, id string, phase string, timeout time.Duration) (*clusters.Clusters, error) {
+	var cluster *clusters.Clusters
+	err := golangsdk.WaitFor(int(timeout.Seconds()), func() (bool, error) {
+		current, err := clusters.Get(cce, id)
+		if err != nil {
+			return false, err
+		}
+
+		cluster = current
+		if current.Status.Phase == ClusterPhaseError {
+			return false, fmt.Errorf("cluster entered phase %s: %s", ClusterPhaseError, current.Status.Reason)
+		}
+		return current.Status.Phase == phase, nil
+	})
+	if err != nil {
+		return cluster, fmt.Errorf("waiting for phase %s: %w", phase, err)
+	}
+	return cluster, nil
+}
+
+// Failed reports whether the result carries an error.
+func (r ClusterActionResult) Failed() bool {
+	return r.Error != ""
+}
@@ -0,0 +1,281 @@
+package cce
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+	"gopkg.in/yaml.v2"
+)
+
+// Kubeconfig is a kubectl configuration file. Fields this tool does not
+// manage are kept in Extra so that rewriting a file preserves them.
+type Kubeconfig struct {
+	APIVersion     string                 `yaml:"apiVersion"`
+	Kind           string                 `yaml:"kind"`
+	Clusters       []NamedCluster         `yaml:"clusters"`
+	Users          []NamedUser            `yaml:"users"`
+	Contexts       []NamedContext         `yaml:"contexts"`
+	CurrentContext string                 `yaml:"current-context"`
+	Extra          map[string]interface{} `yaml:",inline"`
+}
+
+type NamedCluster struct {
+	Name    string            `yaml:"name"`
+	Cluster KubeconfigCluster `yaml:"cluster"`
+}
+
+type KubeconfigCluster struct {
+	Server                   string                 `yaml:"server"`
+	CertificateAuthorityData string                 `yaml:"certificate-authority-data,omitempty"`
+	InsecureSkipTLSVerify    bool                   `yaml:"insecure-skip-tls-verify,omitempty"`
+	Extra                    map[string]interface{} `yaml:",inline"`
+}
+
+type NamedUser struct {
+	Name string         `yaml:"name"`
+	User KubeconfigUser `yaml:"user"`
+}
+
+type KubeconfigUser struct {
+	ClientCertificateData string                 `yaml:"client-certificate-data,omitempty"`
+	ClientKeyData         string                 `yaml:"client-key-data,omitempty"`
+	Exec                  *ExecConfig            `yaml:"exec,omitempty"`
+	Extra                 map[string]interface{} `yaml:",inline"`
+}
+
+// ExecConfig runs a command to obtain the user's credentials.
+type ExecConfig struct {
+	APIVersion      string                 `yaml:"apiVersion"`
+	Command         string                 `yaml:"command"`
+	Args            []string               `yaml:"args,omitempty"`
+	InteractiveMode string                 `yaml:"interactiveMode,omitempty"`
+	Extra           map[string]interface{} `yaml:",inline"`
+}
+
+type NamedContext struct {
+	Name    string            `yaml:"name"`
+	Context KubeconfigContext `yaml:"context"`
+}
+
+type KubeconfigContext struct {
+	Cluster    string                 `yaml:"cluster"`
+	User       string                 `yaml:"user"`
+	Namespace  string                 `yaml:"namespace,omitempty"`
+	Extensions []NamedExtension       `yaml:"extensions,omitempty"`
+	Extra      map[string]interface{} `yaml:",inline"`
+}
+
+type NamedExtension struct {
+	Name      string      `yaml:"name"`
+	Extension interface{} `yaml:"extension"`
+}
+
+// ownerExtension names the context extension recording the cloud and
+// cluster a context was generated for. Context names cannot be used for
+// this, since cloud and cluster names may both contain dashes.
+const ownerExtension = "otc-cli"
+
+// setOwner records the cloud and cluster the context belongs to.
+func (c *KubeconfigContext) setOwner(cloudName, clusterName string) {
+	c.Extensions = upsert(c.Extensions, NamedExtension{
+		Name: ownerExtension,
+		Extension: map[string]interface{}{
+			"cloud":   cloudName,
+			"cluster": clusterName,
+		},
+	}, func(e NamedExtension) string { return e.Name })
+}
+
+// owner returns the cloud and cluster recorded by setOwner. It returns false
+// for contexts not generated by this tool.
+func (c *KubeconfigContext) owner() (string, string, bool) {
+	for _, e := range c.Extensions {
+		if e.Name != ownerExtension {
+			continue
+		}
+		cloudName, clusterName := extensionString(e.Extension, "cloud"), extensionString(e.Extension, "cluster")
+		return cloudName, clusterName, cloudName != "" && clusterName != ""
+	}
+	return "", "", false
+}
+
+// extensionString returns a string field of an extension, which is a
+// generic map once read back from a file.
+func extensionString(extension interface{}, key string) string {
+	var value interface{}
+	switch m := extension.(type) {
+	case map[string]interface{}:
+		value = m[key]
+	case map[interface{}]interface{}:
+		value = m[key]
+	}
+	s, _ := value.(string)
+	return s
+}
+
+// ContextName returns the name used for the cluster, user and context
+// entries of a CCE cluster.
+func ContextName(cloudName, clusterName string) string {
+	if cloudName == "" {
+		cloudName = "default"
+	}
+	return fmt.Sprintf("otc-%s-%s", cloudName, clusterName)
+}
+
+// DefaultKubeconfigPath returns the first file listed in $KUBECONFIG, or
+// ~/.kube/config when it is not set.
+func DefaultKubeconfigPath() (string, error) {
+	for _, path := range filepath.SplitList(os.Getenv("KUBECONFIG")) {
+		if path != "" {
+			return path, nil
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// kubeconfigFromCertificate converts the certificate returned by CCE into a
+// kubeconfig with a single context named name, built from the certificate's
+// context contextName (its current context when empty).
+func kubeconfigFromCertificate(cert *clusters.Certificate, name string, contextName string) (*Kubeconfig, error) {
+	if contextName == "" {
+		contextName = cert.CurrentContext
+	}
+
+	var context *clusters.CertContext
+	for _, c := range cert.Contexts {
+		if c.Name == contextName {
+			context = &c.Context
+			break
+		}
+	}
+	if context == nil {
+		return nil, fmt.Errorf("cluster certificate has no context '%s'", contextName)
+	}
+
+	kubeconfig := &Kubeconfig{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Contexts: []NamedContext{{
+			Name:    name,
+			Context: KubeconfigContext{Cluster: name, User: name},
+		}},
+		CurrentContext: name,
+	}
+
+	for _, c := range cert.Clusters {
+		if c.Name == context.Cluster {
+			kubeconfig.Clusters = append(kubeconfig.Clusters, NamedCluster{
+				Name: name,
+				Cluster: KubeconfigCluster{
+					Server:                   c.Cluster.Server,
+					CertificateAuthorityData: c.Cluster.CertAuthorityData,
+					InsecureSkipTLSVerify:    c.Cluster.InsecureSkipTLSVerify,
+				},
+			})
+		}
+	}
+	for _, u := range cert.Users {
+		if u.Name == context.User {
+			kubeconfig.Users = append(kubeconfig.Users, NamedUser{
+				Name: name,
+				User: KubeconfigUser{
+					ClientCertificateData: u.User.ClientCertData,
+					ClientKeyData:         u.User.ClientKeyData,
+				},
+			})
+		}
+	}
+
+	if len(kubeconfig.Clusters) != 1 || len(kubeconfig.Users) != 1 {
+		return nil, fmt.Errorf("cluster certificate is missing cluster '%s' or user '%s'", context.Cluster, context.User)
+	}
+	return kubeconfig, nil
+}
+
+// Merge adds the clusters, users and contexts of other, replacing entries
+// with the same name and keeping all others. The current context is taken
+// over when setCurrent is true or none is set yet.
+func (k *Kubeconfig) Merge(other *Kubeconfig, setCurrent bool) {
+	if k.APIVersion == "" {
+		k.APIVersion = "v1"
+	}
+	if k.Kind == "" {
+		k.Kind = "Config"
+	}
+
+	for _, c := range other.Clusters {
+		k.Clusters = upsert(k.Clusters, c, func(e NamedCluster) string { return e.Name })
+	}
+	for _, u := range other.Users {
+		k.Users = upsert(k.Users, u, func(e NamedUser) string { return e.Name })
+	}
+	for _, c := range other.Contexts {
+		k.Contexts = upsert(k.Contexts, c, func(e NamedContext) string { return e.Name })
+	}
+
+	if setCurrent || k.CurrentContext == "" {
+		k.CurrentContext = other.CurrentContext
+	}
+}
+
+func upsert[T any](entries []T, entry T, name func(T) string) []T {
+	for i := range entries {
+		if name(entries[i]) == name(entry) {
+			entries[i] = entry
+			return entries
+		}
+	}
+	return append(entries, entry)
+}
+
+// LoadKubeconfig reads a kubeconfig file. A missing file yields an empty
+// configuration.
+func LoadKubeconfig(path string) (*Kubeconfig, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Kubeconfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig %s: %w", path, err)
+	}
+
+	var kubeconfig Kubeconfig
+	if err := yaml.Unmarshal(data, &kubeconfig); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig %s: %w", path, err)
+	}
+	return &kubeconfig, nil
+}
+
+// Marshal returns the kubeconfig as YAML.
+func (k *Kubeconfig) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal kubeconfig: %w", err)
+	}
+	return data, nil
+}
+
+// Save writes the kubeconfig readable only by the current user.
+func (k *Kubeconfig) Save(path string) error {
+	data, err := k.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("unable to write kubeconfig to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package cce
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/clusters"
+)
+
+func testCertificate() *clusters.Certificate {
+	return &clusters.Certificate{
+		Kind:       "Config",
+		ApiVersion: "v1",
+		Clusters: []clusters.CertClusters{
+			{Name: "internalCluster", Cluster: clusters.CertCluster{Server: "https://192.168.0.10:5443", CertAuthorityData: "bmV3LWNh"}},
+			{Name: "externalCluster", Cluster: clusters.CertCluster{Server: "https://80.158.1.1:5443", InsecureSkipTLSVerify: true}},
+		},
+		Users: []clusters.CertUsers{
+			{Name: "user", User: clusters.CertUser{ClientCertData: "bmV3LWNlcnQ=", ClientKeyData: "bmV3LWtleQ=="}},
+		},
+		Contexts: []clusters.CertContexts{
+			{Name: "internal", Context: clusters.CertContext{Cluster: "internalCluster", User: "user"}},
+			{Name: "external", Context: clusters.CertContext{Cluster: "externalCluster", User: "user"}},
+		},
+		CurrentContext: "internal",
+	}
+}
+
+// mergeFixture merges the test certificate as context name into a copy of
+// the fixture file and returns the configuration read back from disk.
+func mergeFixture(t *testing.T, fixture string, name string, setCurrent bool) *Kubeconfig {
+	t.Helper()
+
+	kubeconfig, err := kubeconfigFromCertificate(testCertificate(), name, "")
+	if err != nil {
+		t.Fatalf("kubeconfigFromCertificate: %v", err)
+	}
+
+	existing, err := LoadKubeconfig(fixture)
+	if err != nil {
+		t.Fatalf("LoadKubeconfig(%s): %v", fixture, err)
+	}
+	existing.Merge(kubeconfig, setCurrent)
+
+	path := filepath.Join(t.TempDir(), "config")
+	if err := existing.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	merged, err := LoadKubeconfig(path)
+	if err != nil {
+		t.Fatalf("LoadKubeconfig(%s): %v", path, err)
+	}
+	return merged
+}
+
+func clusterEntry(k *Kubeconfig, name string) *KubeconfigCluster {
+	for _, c := range k.Clusters {
+		if c.Name == name {
+			return &c.Cluster
+		}
+	}
+	return nil
+}
+
+func userEntry(k *Kubeconfig, name string) *KubeconfigUser {
+	for _, u := range k.Users {
+		if u.Name == name {
+			return &u.User
+		}
+	}
+	return nil
+}
+
+func contextEntry(k *Kubeconfig, name string) *KubeconfigContext {
+	for _, c := range k.Contexts {
+		if c.Name == name {
+			return &c.Context
+		}
+	}
+	return nil
+}
+
+func TestKubeconfigFromCertificate(t *testing.T) {
+	kubeconfig, err := kubeconfigFromCertificate(testCertificate(), "otc-test-app", "")
+	if err != nil {
+		t.Fatalf("kubeconfigFromCertificate: %v", err)
+	}
+
+	if kubeconfig.CurrentContext != "otc-test-app" {
+		t.Errorf("current context = %q, want otc-test-app", kubeconfig.CurrentContext)
+	}
+	if c := clusterEntry(kubeconfig, "otc-test-app"); c == nil || c.Server != "https://192.168.0.10:5443" {
+		t.Errorf("cluster = %+v, want the internal endpoint", c)
+	}
+	if u := userEntry(kubeconfig, "otc-test-app"); u == nil || u.ClientKeyData != "bmV3LWtleQ==" {
+		t.Errorf("user = %+v, want the certificate user", u)
+	}
+	if c := contextEntry(kubeconfig, "otc-test-app"); c == nil || c.Cluster != "otc-test-app" || c.User != "otc-test-app" {
+		t.Errorf("context = %+v, want it to reference otc-test-app", c)
+	}
+
+	if _, err := kubeconfigFromCertificate(testCertificate(), "otc-test-app", "missing"); err == nil {
+		t.Error("expected an error for a missing certificate context")
+	}
+}
+
+func TestMergeKeepsUnrelatedEntries(t *testing.T) {
+	merged := mergeFixture(t, "testdata/existing.yaml", "otc-test-app", false)
+
+	if merged.CurrentContext != "minikube" {
+		t.Errorf("current context = %q, want it unchanged", merged.CurrentContext)
+	}
+	if len(merged.Clusters) != 3 || len(merged.Users) != 4 || len(merged.Contexts) != 3 {
+		t.Errorf("got %d clusters, %d users, %d contexts, want 3, 4, 3", len(merged.Clusters), len(merged.Users), len(merged.Contexts))
+	}
+
+	minikube := clusterEntry(merged, "minikube")
+	if minikube == nil || minikube.Extra["certificate-authority"] != "/home/user/.minikube/ca.crt" || minikube.Extra["extensions"] == nil {
+		t.Errorf("minikube cluster lost fields: %+v", minikube)
+	}
+	if eks := userEntry(merged, "eks-user"); eks == nil || eks.Exec == nil || eks.Exec.Command != "aws" || len(eks.Exec.Args) != 2 {
+		t.Errorf("eks-user lost its exec config: %+v", eks)
+	}
+	if c := contextEntry(merged, "minikube"); c == nil || c.Namespace != "default" {
+		t.Errorf("minikube context lost its namespace: %+v", c)
+	}
+	if merged.Extra["preferences"] == nil {
+		t.Error("preferences were dropped")
+	}
+	if contextEntry(merged, "otc-test-app") == nil {
+		t.Error("new context was not added")
+	}
+}
+
+func TestMergeReplacesEntriesWithSameName(t *testing.T) {
+	merged := mergeFixture(t, "testdata/existing.yaml", "otc-prod-app", false)
+
+	if len(merged.Clusters) != 2 || len(merged.Users) != 3 || len(merged.Contexts) != 2 {
+		t.Errorf("got %d clusters, %d users, %d contexts, want 2, 3, 2", len(merged.Clusters), len(merged.Users), len(merged.Contexts))
+	}
+	if c := clusterEntry(merged, "otc-prod-app"); c == nil || c.CertificateAuthorityData != "bmV3LWNh" {
+		t.Errorf("cluster was not replaced: %+v", c)
+	}
+	if u := userEntry(merged, "otc-prod-app"); u == nil || u.ClientCertificateData != "bmV3LWNlcnQ=" {
+		t.Errorf("user was not replaced: %+v", u)
+	}
+}
+
+func TestMergeSetsCurrentContext(t *testing.T) {
+	merged := mergeFixture(t, "testdata/existing.yaml", "otc-test-app", true)
+
+	if merged.CurrentContext != "otc-test-app" {
+		t.Errorf("current context = %q, want otc-test-app", merged.CurrentContext)
+	}
+}
+
+func TestMergeIntoMissingFile(t *testing.T) {
+	merged := mergeFixture(t, filepath.Join(t.TempDir(), "missing"), "otc-test-app", false)
+
+	if merged.APIVersion != "v1" || merged.Kind != "Config" {
+		t.Errorf("got apiVersion %q and kind %q, want v1 and Config", merged.APIVersion, merged.Kind)
+	}
+	if merged.CurrentContext != "otc-test-app" {
+		t.Errorf("current context = %q, want the only context", merged.CurrentContext)
+	}
+	if len(merged.Clusters) != 1 || len(merged.Users) != 1 || len(merged.Contexts) != 1 {
+		t.Errorf("got %d clusters, %d users, %d contexts, want one each", len(merged.Clusters), len(merged.Users), len(merged.Contexts))
+	}
+}
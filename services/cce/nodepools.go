@@ -0,0 +1,281 @@
+package cce
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"otc-cli/config"
+	"otc-cli/errs"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodepools"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
+)
+
+const (
+	NodePoolPhaseSynchronizing = "Synchronizing"
+	NodePoolPhaseDeleting      = "Deleting"
+	NodePoolPhaseError         = "Error"
+)
+
+// ErrDeleteAborted is returned when the deletion was not confirmed.
+var ErrDeleteAborted = errors.New("deletion aborted")
+
+type NodePoolArgs struct {
+	CommonConfig *config.CommonConfig
+	ClusterName  string
+	NodePool     string
+}
+
+func ListNodePools(clusterName string, commonConfig *config.CommonConfig) ([]nodepools.NodePool, error) {
+	cce, err := getCCEClouds(commonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	poolList, err := nodepools.List(cce, cluster.Metadata.Id, nodepools.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node pools: %w", err)
+	}
+	return poolList, nil
+}
+
+func ShowNodePool(args NodePoolArgs) (*nodepools.NodePool, error) {
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+	return findNodePool(cce, cluster.Metadata.Id, args.NodePool)
+}
+
+type ScaleNodePoolArgs struct {
+	NodePoolArgs
+	Count   int
+	Wait    bool
+	Timeout time.Duration
+}
+
+// ScaleNodePool changes the number of nodes of a node pool. With autoscaling
+// enabled the count has to stay within the autoscaling limits.
+func ScaleNodePool(args ScaleNodePoolArgs) (*nodepools.NodePool, error) {
+	if args.Count < 0 {
+		return nil, fmt.Errorf("node count must not be negative")
+	}
+
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := findNodePool(cce, cluster.Metadata.Id, args.NodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	autoscaling := pool.Spec.Autoscaling
+	if autoscaling.Enable && (args.Count < autoscaling.MinNodeCount || args.Count > autoscaling.MaxNodeCount) {
+		return nil, fmt.Errorf("node pool %s autoscales between %d and %d nodes", pool.Metadata.Name, autoscaling.MinNodeCount, autoscaling.MaxNodeCount)
+	}
+
+	// The update replaces the node template labels, taints and autoscaling
+	// settings, so the current ones are sent along.
+	_, err = nodepools.Update(cce, cluster.Metadata.Id, pool.Metadata.Id, nodepools.UpdateOpts{
+		Metadata: nodepools.UpdateMetaData{Name: pool.Metadata.Name},
+		Spec: nodepools.UpdateSpec{
+			NodeTemplate: nodepools.UpdateNodeTemplate{
+				K8sTags: pool.Spec.NodeTemplate.K8sTags,
+				Taints:  pool.Spec.NodeTemplate.Taints,
+			},
+			InitialNodeCount: args.Count,
+			Autoscaling: nodepools.UpdateAutoscalingSpec{
+				Enable:                autoscaling.Enable,
+				MinNodeCount:          autoscaling.MinNodeCount,
+				MaxNodeCount:          autoscaling.MaxNodeCount,
+				ScaleDownCooldownTime: autoscaling.ScaleDownCooldownTime,
+				Priority:              autoscaling.Priority,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale node pool: %w", err)
+	}
+
+	if args.Wait {
+		return waitForNodeCount(cce, cluster.Metadata.Id, pool.Metadata.Id, args.Count, int(args.Timeout.Seconds()))
+	}
+	return nodepools.Get(cce, cluster.Metadata.Id, pool.Metadata.Id)
+}
+
+type CreateNodePoolArgs struct {
+	NodePoolArgs
+	Flavor           string
+	AvailabilityZone string
+	OS               string
+	KeyPair          string
+	Count            int
+	RootVolumeSize   int
+	RootVolumeType   string
+	DataVolumeSize   int
+	DataVolumeType   string
+	// MaxNodes enables autoscaling between MinNodes and MaxNodes when set.
+	MinNodes int
+	MaxNodes int
+	Wait     bool
+	Timeout  time.Duration
+}
+
+func CreateNodePool(args CreateNodePoolArgs) (*nodepools.NodePool, error) {
+	if args.Flavor == "" {
+		return nil, errors.New("flavor is required")
+	}
+	if args.KeyPair == "" {
+		return nil, errors.New("key pair is required")
+	}
+	if args.MaxNodes > 0 && (args.Count < args.MinNodes || args.Count > args.MaxNodes) {
+		return nil, fmt.Errorf("node count must be between %d and %d", args.MinNodes, args.MaxNodes)
+	}
+
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := nodepools.Create(cce, cluster.Metadata.Id, nodepools.CreateOpts{
+		Kind:       "NodePool",
+		ApiVersion: "v3",
+		Metadata:   nodepools.CreateMetaData{Name: args.NodePool},
+		Spec: nodepools.CreateSpec{
+			Type: "vm",
+			NodeTemplate: nodes.Spec{
+				Flavor: args.Flavor,
+				Az:     args.AvailabilityZone,
+				Os:     args.OS,
+				Login:  nodes.LoginSpec{SshKey: args.KeyPair},
+				RootVolume: nodes.VolumeSpec{
+					Size:       args.RootVolumeSize,
+					VolumeType: args.RootVolumeType,
+				},
+				DataVolumes: []nodes.VolumeSpec{{
+					Size:       args.DataVolumeSize,
+					VolumeType: args.DataVolumeType,
+				}},
+				Count: 1,
+			},
+			InitialNodeCount: args.Count,
+			Autoscaling: nodepools.AutoscalingSpec{
+				Enable:       args.MaxNodes > 0,
+				MinNodeCount: args.MinNodes,
+				MaxNodeCount: args.MaxNodes,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create node pool: %w", err)
+	}
+
+	if args.Wait {
+		return waitForNodeCount(cce, cluster.Metadata.Id, pool.Metadata.Id, args.Count, int(args.Timeout.Seconds()))
+	}
+	return pool, nil
+}
+
+type DeleteNodePoolArgs struct {
+	NodePoolArgs
+	// Confirm is called with the node pool about to be deleted. Deletion
+	// only proceeds when it returns true.
+	Confirm func(pool nodepools.NodePool) (bool, error)
+}
+
+// DeleteNodePool deletes a node pool together with its nodes.
+func DeleteNodePool(args DeleteNodePoolArgs) error {
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	pool, err := findNodePool(cce, cluster.Metadata.Id, args.NodePool)
+	if err != nil {
+		return err
+	}
+
+	if args.Confirm != nil {
+		ok, err := args.Confirm(*pool)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrDeleteAborted
+		}
+	}
+
+	if err := nodepools.Delete(cce, cluster.Metadata.Id, pool.Metadata.Id); err != nil {
+		return fmt.Errorf("failed to delete node pool: %w", err)
+	}
+	return nil
+}
+
+// findNodePool returns the node pool of the cluster with the given name or ID.
+func findNodePool(cce *golangsdk.ServiceClient, clusterID string, nameOrID string) (*nodepools.NodePool, error) {
+	poolList, err := nodepools.List(cce, clusterID, nodepools.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node pools: %w", err)
+	}
+
+	for _, p := range poolList {
+		if p.Metadata.Id == nameOrID || p.Metadata.Name == nameOrID {
+			return &p, nil
+		}
+	}
+	return nil, &errs.NotFoundError{Resource: "node pool", Name: nameOrID}
+}
+
+// waitForNodeCount polls a node pool until it has count nodes and is no
+// longer synchronizing, failing early when the pool enters the error phase.
+func waitForNodeCount(cce *golangsdk.ServiceClient, clusterID, poolID string, count int, secs int) (*nodepools.NodePool, error) {
+	var pool *nodepools.NodePool
+	err := golangsdk.WaitFor(secs, func() (bool, error) {
+		var err error
+		pool, err = nodepools.Get(cce, clusterID, poolID)
+		if err != nil {
+			return false, err
+		}
+
+		switch pool.Status.Phase {
+		case NodePoolPhaseError:
+			return false, fmt.Errorf("node pool is in phase %s", pool.Status.Phase)
+		case NodePoolPhaseSynchronizing, NodePoolPhaseDeleting:
+			return false, nil
+		}
+		return pool.Status.CurrentNode == count, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for node pool %s to have %d nodes: %w", poolID, count, err)
+	}
+	return pool, nil
+}
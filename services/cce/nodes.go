@@ -0,0 +1,55 @@
+package cce
+
+import (
+	"fmt"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/nodes"
+)
+
+const (
+	// nodePoolAnnotation references the node pool a node belongs to.
+	nodePoolAnnotation = "kubernetes.io/node-pool.id"
+)
+
+type NodesListArgs struct {
+	CommonConfig *config.CommonConfig
+	ClusterName  string
+	// NodePool only lists the nodes of the node pool with this name or ID.
+	NodePool string
+}
+
+func ListNodes(args NodesListArgs) ([]nodes.Nodes, error) {
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList, err := nodes.List(cce, cluster.Metadata.Id, nodes.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	if args.NodePool == "" {
+		return nodeList, nil
+	}
+
+	pool, err := findNodePool(cce, cluster.Metadata.Id, args.NodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []nodes.Nodes
+	for _, n := range nodeList {
+		if n.Metadata.Annotations[nodePoolAnnotation] == pool.Metadata.Id {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered, nil
+}
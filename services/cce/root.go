@@ -1,11 +1,10 @@
 package cce
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"otc-cli/client"
 	"otc-cli/config"
+	"time"
 
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
@@ -20,7 +19,7 @@ func getCCEClouds(commonConfig *config.CommonConfig) (*golangsdk.ServiceClient,
 
 	client, err := client.GetAuthenticatedClient(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate client: %s", err)
+		return nil, fmt.Errorf("failed to authenticate client: %w", err)
 	}
 
 	return openstack.NewCCE(client, golangsdk.EndpointOpts{
@@ -28,63 +27,128 @@ func getCCEClouds(commonConfig *config.CommonConfig) (*golangsdk.ServiceClient,
 	})
 }
 
-func List(commonConfig *config.CommonConfig) ([]clusters.Clusters, error) {
-	cce, err := getCCEClouds(commonConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create CCE client: %w", err)
-	}
+// KubeconfigOptions control how the kubeconfig of a cluster is generated.
+type KubeconfigOptions struct {
+	// Exec authenticates through `otc cce credential` instead of embedding
+	// a non-expiring client certificate.
+	Exec bool
+	// Endpoint selects the API server address, EndpointInternal or
+	// EndpointExternal. The certificate's default is used when empty.
+	Endpoint string
+	// Expiry is the validity of the embedded client certificate, rounded up
+	// to whole days. It does not expire when zero.
+	Expiry time.Duration
+}
 
-	clusterList, err := clusters.List(cce, clusters.ListOpts{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list clusters: %w", err)
+func (o KubeconfigOptions) validate() error {
+	if o.Exec && o.Expiry != 0 {
+		return fmt.Errorf("an expiry cannot be combined with exec credentials")
 	}
-
-	return clusterList, nil
+	if o.Expiry < 0 {
+		return fmt.Errorf("expiry must be positive")
+	}
+	return nil
 }
 
 type ConfigArgs struct {
+	KubeconfigOptions
 	CommonConfig *config.CommonConfig
 	ClusterName  string
-	OutputPath   string
+	// OutputPath is the kubeconfig to merge into, by default the one kubectl uses.
+	OutputPath string
+	// Stdout prints the kubeconfig of the cluster instead of merging it.
+	Stdout bool
+	// SetCurrentContext makes the cluster the current context.
+	SetCurrentContext bool
 }
 
 func Config(args ConfigArgs) error {
+	if err := args.validate(); err != nil {
+		return err
+	}
+
 	cce, err := getCCEClouds(args.CommonConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create CCE client: %w", err)
 	}
 
-	clusterList, err := clusters.List(cce, clusters.ListOpts{Name: args.ClusterName})
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	kubeconfig, err := clusterKubeconfig(cce, args.CommonConfig, cluster.Clusters, args.KubeconfigOptions)
+	if err != nil {
+		return err
+	}
+
+	if args.Stdout {
+		data, err := kubeconfig.Marshal()
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(data))
+		return nil
+	}
+
+	path, err := kubeconfigPath(args.OutputPath)
 	if err != nil {
-		return fmt.Errorf("failed to list clusters: %w", err)
+		return err
 	}
 
-	if len(clusterList) == 0 {
-		return fmt.Errorf("cluster '%s' not found", args.ClusterName)
+	merged, err := LoadKubeconfig(path)
+	if err != nil {
+		return err
+	}
+	merged.Merge(kubeconfig, args.SetCurrentContext)
+	if err := merged.Save(path); err != nil {
+		return err
 	}
 
+	fmt.Printf("Context %s written to %s\n", kubeconfig.CurrentContext, path)
+	return nil
+}
+
+// clusterKubeconfig fetches a certificate for the cluster and converts it
+// into a kubeconfig with a context named by ContextName. The context records
+// the cloud and cluster it belongs to for pruning.
+func clusterKubeconfig(cce *golangsdk.ServiceClient, commonConfig *config.CommonConfig, cluster clusters.Clusters, opts KubeconfigOptions) (*Kubeconfig, error) {
+	// The exec plugin fetches its own client certificates, the one
+	// requested here only provides the cluster endpoint.
 	expiryOpts := clusters.ExpirationOpts{
 		Duration: -1,
 	}
-	kubeconfig, err := clusters.GetCertWithExpiration(cce, clusterList[0].Metadata.Id, expiryOpts)
+	if opts.Exec {
+		expiryOpts.Duration = credentialDays
+	} else if opts.Expiry > 0 {
+		expiryOpts.Duration = expiryDays(opts.Expiry)
+	}
+	cert, err := clusters.GetCertWithExpiration(cce, cluster.Metadata.Id, expiryOpts)
 	if err != nil {
-		return fmt.Errorf("unable to retrieve cluster kubeconfig: %w", err)
+		return nil, fmt.Errorf("unable to retrieve cluster kubeconfig: %w", err)
 	}
 
-	configJson, err := json.MarshalIndent(kubeconfig, "", "  ")
+	certContext, err := endpointContext(cert, opts.Endpoint)
 	if err != nil {
-		return fmt.Errorf("unable to marshal cluster kubeconfig: %w", err)
+		return nil, err
 	}
 
-	if args.OutputPath != "" {
-		if err := os.WriteFile(args.OutputPath, configJson, 0600); err != nil {
-			return fmt.Errorf("unable to write kubeconfig to file: %w", err)
-		}
-		fmt.Printf("Kubeconfig written to %s\n", args.OutputPath)
-		return nil
-	} else {
-		fmt.Println(string(configJson))
+	contextName := ContextName(commonConfig.CloudName, cluster.Metadata.Name)
+	kubeconfig, err := kubeconfigFromCertificate(cert, contextName, certContext)
+	if err != nil {
+		return nil, err
 	}
+	kubeconfig.Contexts[0].Context.setOwner(commonConfig.CloudName, cluster.Metadata.Name)
+	if opts.Exec {
+		kubeconfig.Users[0].User = ExecUser(commonConfig, cluster.Metadata.Name)
+	}
+	return kubeconfig, nil
+}
 
-	return nil
+// kubeconfigPath returns path, or the default kubeconfig when it is empty.
+func kubeconfigPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	return DefaultKubeconfigPath()
 }
@@ -0,0 +1,220 @@
+package cce
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"otc-cli/config"
+	"otc-cli/errs"
+)
+
+const (
+	SyncMerged = "merged"
+	SyncPruned = "pruned"
+	SyncFailed = "failed"
+)
+
+type SyncArgs struct {
+	KubeconfigOptions
+	CommonConfig *config.CommonConfig
+	// Clouds are the clouds from clouds.yaml to sync, all of them when empty.
+	Clouds []string
+	// OutputPath is the kubeconfig to merge into, by default the one kubectl uses.
+	OutputPath string
+	// Prune removes contexts of the synced clouds whose cluster no longer
+	// exists.
+	Prune bool
+}
+
+// SyncResult is the outcome of syncing a single cluster. Failures to list
+// the clusters of a cloud are reported with an empty cluster.
+type SyncResult struct {
+	Cloud   string `json:"cloud"`
+	Cluster string `json:"cluster,omitempty"`
+	Context string `json:"context,omitempty"`
+	Action  string `json:"action"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Sync merges the kubeconfig of every cluster in the selected clouds into a
+// single kubeconfig file. Clouds are queried concurrently. Contexts are only
+// pruned for clouds whose clusters could all be listed.
+func Sync(args SyncArgs) ([]SyncResult, string, error) {
+	if err := args.validate(); err != nil {
+		return nil, "", err
+	}
+
+	cloudNames, err := syncClouds(args.CommonConfig, args.Clouds)
+	if err != nil {
+		return nil, "", err
+	}
+
+	path, err := kubeconfigPath(args.OutputPath)
+	if err != nil {
+		return nil, "", err
+	}
+	merged, err := LoadKubeconfig(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cloudResults := make([][]SyncResult, len(cloudNames))
+	kubeconfigs := make([][]*Kubeconfig, len(cloudNames))
+
+	var wg sync.WaitGroup
+	for i, cloudName := range cloudNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cloudResults[i], kubeconfigs[i] = syncCloud(args.CommonConfig.ForCloud(cloudName), args.KubeconfigOptions)
+		}()
+	}
+	wg.Wait()
+
+	var results []SyncResult
+	synced := map[string][]string{}
+	owners := map[string]SyncResult{}
+	for i, cloudName := range cloudNames {
+		for j, result := range cloudResults[i] {
+			if kubeconfig := kubeconfigs[i][j]; kubeconfig != nil {
+				// Context names of different clouds may collide, e.g. cluster
+				// eu-app of cloud prod and cluster app of cloud prod-eu.
+				if owner, ok := owners[result.Context]; ok {
+					result.Action = SyncFailed
+					result.Error = fmt.Sprintf("context name is already used by cluster %s of cloud %s", owner.Cluster, owner.Cloud)
+				} else {
+					owners[result.Context] = result
+					merged.Merge(kubeconfig, false)
+				}
+			}
+			results = append(results, result)
+		}
+
+		if errs.FailedCount(cloudResults[i]) == 0 {
+			synced[cloudName] = []string{}
+			for _, r := range cloudResults[i] {
+				synced[cloudName] = append(synced[cloudName], r.Cluster)
+			}
+		}
+	}
+
+	if args.Prune {
+		results = append(results, merged.prune(synced)...)
+	}
+
+	if err := merged.Save(path); err != nil {
+		return results, path, err
+	}
+	return results, path, nil
+}
+
+// syncCloud fetches the kubeconfigs of all clusters in a cloud. The
+// kubeconfigs are returned in the order of the results, nil where fetching
+// failed.
+func syncCloud(commonConfig *config.CommonConfig, opts KubeconfigOptions) ([]SyncResult, []*Kubeconfig) {
+	cce, err := getCCEClouds(commonConfig)
+	if err != nil {
+		return []SyncResult{{Cloud: commonConfig.CloudName, Action: SyncFailed, Error: fmt.Sprintf("failed to create CCE client: %s", err)}}, []*Kubeconfig{nil}
+	}
+
+	clusterList, err := listClusters(cce)
+	if err != nil {
+		return []SyncResult{{Cloud: commonConfig.CloudName, Action: SyncFailed, Error: err.Error()}}, []*Kubeconfig{nil}
+	}
+
+	var results []SyncResult
+	var kubeconfigs []*Kubeconfig
+	for _, cluster := range clusterList {
+		result := SyncResult{
+			Cloud:   commonConfig.CloudName,
+			Cluster: cluster.Metadata.Name,
+			Context: ContextName(commonConfig.CloudName, cluster.Metadata.Name),
+			Action:  SyncMerged,
+		}
+
+		kubeconfig, err := clusterKubeconfig(cce, commonConfig, cluster.Clusters, opts)
+		if err != nil {
+			result.Action = SyncFailed
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+		kubeconfigs = append(kubeconfigs, kubeconfig)
+	}
+	return results, kubeconfigs
+}
+
+// syncClouds validates the requested clouds against clouds.yaml, defaulting
+// to all configured clouds.
+func syncClouds(commonConfig *config.CommonConfig, requested []string) ([]string, error) {
+	configured := configuredClouds(commonConfig)
+	if len(requested) == 0 {
+		if len(configured) == 0 {
+			return nil, fmt.Errorf("no clouds configured in clouds.yaml")
+		}
+		return configured, nil
+	}
+
+	for _, name := range requested {
+		if !slices.Contains(configured, name) {
+			return nil, &errs.NotFoundError{Resource: "cloud", Name: name}
+		}
+	}
+	return requested, nil
+}
+
+func configuredClouds(commonConfig *config.CommonConfig) []string {
+	if commonConfig.Clouds == nil {
+		return nil
+	}
+
+	var names []string
+	for name := range commonConfig.Clouds.Clouds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// prune removes the contexts of the synced clouds whose cluster is not
+// among the clusters just synced, together with their cluster and user
+// entries. synced maps each cloud to its clusters. Contexts are attributed
+// to clouds by the owner recorded in them, contexts without one are kept.
+func (k *Kubeconfig) prune(synced map[string][]string) []SyncResult {
+	var pruned []SyncResult
+	var clusters, users []string
+	k.Contexts = slices.DeleteFunc(k.Contexts, func(c NamedContext) bool {
+		cloudName, clusterName, ok := c.Context.owner()
+		if !ok {
+			return false
+		}
+		if existing, synced := synced[cloudName]; !synced || slices.Contains(existing, clusterName) {
+			return false
+		}
+
+		pruned = append(pruned, SyncResult{
+			Cloud:   cloudName,
+			Cluster: clusterName,
+			Context: c.Name,
+			Action:  SyncPruned,
+		})
+		clusters = append(clusters, c.Context.Cluster)
+		users = append(users, c.Context.User)
+		if c.Name == k.CurrentContext {
+			k.CurrentContext = ""
+		}
+		return true
+	})
+	k.Clusters = slices.DeleteFunc(k.Clusters, func(c NamedCluster) bool {
+		return slices.Contains(clusters, c.Name)
+	})
+	k.Users = slices.DeleteFunc(k.Users, func(u NamedUser) bool {
+		return slices.Contains(users, u.Name)
+	})
+	return pruned
+}
+
+// Failed reports whether the result carries an error.
+func (r SyncResult) Failed() bool {
+	return r.Error != ""
+}
@@ -0,0 +1,113 @@
+package cce
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// ownedKubeconfig returns a kubeconfig for a cluster as synced from cloud.
+func ownedKubeconfig(t *testing.T, cloudName, clusterName string) *Kubeconfig {
+	t.Helper()
+
+	kubeconfig, err := kubeconfigFromCertificate(testCertificate(), ContextName(cloudName, clusterName), "")
+	if err != nil {
+		t.Fatalf("kubeconfigFromCertificate: %v", err)
+	}
+	kubeconfig.Contexts[0].Context.setOwner(cloudName, clusterName)
+	return kubeconfig
+}
+
+// prunedContexts returns the names of the contexts in the results.
+func prunedContexts(results []SyncResult) []string {
+	var names []string
+	for _, r := range results {
+		names = append(names, r.Context)
+	}
+	return names
+}
+
+func TestPrune(t *testing.T) {
+	kubeconfig, err := LoadKubeconfig("testdata/existing.yaml")
+	if err != nil {
+		t.Fatalf("LoadKubeconfig: %v", err)
+	}
+	kubeconfig.Merge(ownedKubeconfig(t, "prod", "web"), false)
+	kubeconfig.Merge(ownedKubeconfig(t, "prod", "old"), false)
+	kubeconfig.Merge(ownedKubeconfig(t, "prod-eu", "app"), false)
+	kubeconfig.CurrentContext = "otc-prod-old"
+
+	pruned := kubeconfig.prune(map[string][]string{"prod": {"web"}})
+
+	if !slices.Equal(prunedContexts(pruned), []string{"otc-prod-old"}) {
+		t.Errorf("pruned %v, want [otc-prod-old]", prunedContexts(pruned))
+	}
+	if pruned[0].Cloud != "prod" || pruned[0].Cluster != "old" || pruned[0].Action != SyncPruned {
+		t.Errorf("result = %+v, want cluster old of cloud prod pruned", pruned[0])
+	}
+	if clusterEntry(kubeconfig, "otc-prod-old") != nil || userEntry(kubeconfig, "otc-prod-old") != nil {
+		t.Error("cluster and user of the pruned context were kept")
+	}
+	if contextEntry(kubeconfig, "otc-prod-web") == nil {
+		t.Error("context of an existing cluster was pruned")
+	}
+	if contextEntry(kubeconfig, "otc-prod-eu-app") == nil {
+		t.Error("context of a cloud that was not synced was pruned")
+	}
+	if contextEntry(kubeconfig, "otc-prod-app") == nil || contextEntry(kubeconfig, "minikube") == nil {
+		t.Error("context without a recorded owner was pruned")
+	}
+	if kubeconfig.CurrentContext != "" {
+		t.Errorf("current context = %q, want it cleared", kubeconfig.CurrentContext)
+	}
+}
+
+// TestPruneCollidingNames checks that contexts are attributed by their
+// recorded owner: cluster eu-app of cloud prod is named like a context of
+// cloud prod-eu.
+func TestPruneCollidingNames(t *testing.T) {
+	kubeconfig := &Kubeconfig{}
+	kubeconfig.Merge(ownedKubeconfig(t, "prod", "eu-app"), false)
+	kubeconfig.Merge(ownedKubeconfig(t, "prod", "eu-old"), false)
+	kubeconfig.Merge(ownedKubeconfig(t, "prod-eu", "web"), false)
+
+	// Owners have to survive a round trip through the file.
+	path := filepath.Join(t.TempDir(), "config")
+	if err := kubeconfig.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	kubeconfig, err := LoadKubeconfig(path)
+	if err != nil {
+		t.Fatalf("LoadKubeconfig: %v", err)
+	}
+
+	if pruned := kubeconfig.prune(map[string][]string{"prod-eu": {"web"}}); len(pruned) != 0 {
+		t.Errorf("syncing prod-eu pruned %v, want nothing", prunedContexts(pruned))
+	}
+
+	pruned := kubeconfig.prune(map[string][]string{"prod": {"eu-app"}})
+	if !slices.Equal(prunedContexts(pruned), []string{"otc-prod-eu-old"}) {
+		t.Errorf("syncing prod pruned %v, want [otc-prod-eu-old]", prunedContexts(pruned))
+	}
+	if contextEntry(kubeconfig, "otc-prod-eu-app") == nil || contextEntry(kubeconfig, "otc-prod-eu-web") == nil {
+		t.Error("contexts of existing clusters were pruned")
+	}
+}
+
+func TestContextOwner(t *testing.T) {
+	context := KubeconfigContext{
+		Extensions: []NamedExtension{{Name: "other", Extension: map[string]interface{}{"cloud": "x"}}},
+	}
+	if _, _, ok := context.owner(); ok {
+		t.Error("context without owner extension has an owner")
+	}
+
+	context.setOwner("prod", "app")
+	context.setOwner("prod", "web")
+	if len(context.Extensions) != 2 {
+		t.Errorf("got %d extensions, want the owner replaced and the other kept", len(context.Extensions))
+	}
+	if cloudName, clusterName, ok := context.owner(); !ok || cloudName != "prod" || clusterName != "web" {
+		t.Errorf("owner = %q, %q, %v, want prod, web", cloudName, clusterName, ok)
+	}
+}
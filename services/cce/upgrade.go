@@ -0,0 +1,155 @@
+package cce
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"otc-cli/config"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/cce/v3/addons"
+)
+
+// UpgradeCheck summarizes what upgrading a cluster involves.
+type UpgradeCheck struct {
+	Cluster        string   `json:"cluster"`
+	CurrentVersion string   `json:"currentVersion"`
+	TargetVersions []string `json:"targetVersions"`
+	// TargetVersion is the version the add-ons were checked against.
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// Addons are the installed add-ons that need an upgrade, either before
+	// the cluster upgrade or because a newer version exists.
+	Addons []AddonUpgrade `json:"addons"`
+}
+
+type AddonUpgrade struct {
+	Name           string `json:"name"`
+	CurrentVersion string `json:"currentVersion"`
+	// LatestVersion is the newest version supporting the target version, or
+	// the newest version available without a target version.
+	LatestVersion string `json:"latestVersion,omitempty"`
+	// Blocking add-ons do not support the target version and have to be
+	// upgraded before the cluster.
+	Blocking bool `json:"blocking"`
+}
+
+type UpgradeCheckArgs struct {
+	CommonConfig *config.CommonConfig
+	ClusterName  string
+	// TargetVersion defaults to the newest version the cluster can be
+	// upgraded to.
+	TargetVersion string
+}
+
+// upgradeInfo is the part of the cluster upgrade information the SDK does
+// not provide.
+type upgradeInfo struct {
+	Spec struct {
+		VersionInfo struct {
+			Release        string   `json:"release"`
+			Patch          string   `json:"patch"`
+			TargetVersions []string `json:"targetVersions"`
+		} `json:"versionInfo"`
+	} `json:"spec"`
+}
+
+// CheckUpgrade lists the versions a cluster can be upgraded to and the
+// installed add-ons that need an upgrade for the target version.
+func CheckUpgrade(args UpgradeCheckArgs) (*UpgradeCheck, error) {
+	cce, err := getCCEClouds(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CCE client: %w", err)
+	}
+
+	cluster, err := findCluster(cce, args.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := getUpgradeInfo(cce, cluster.Metadata.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &UpgradeCheck{
+		Cluster:        cluster.Metadata.Name,
+		CurrentVersion: cluster.Spec.Version,
+		TargetVersions: info.Spec.VersionInfo.TargetVersions,
+		TargetVersion:  args.TargetVersion,
+		Addons:         []AddonUpgrade{},
+	}
+	if release := info.Spec.VersionInfo.Release; release != "" {
+		check.CurrentVersion = release
+		if patch := info.Spec.VersionInfo.Patch; patch != "" {
+			check.CurrentVersion += "-" + patch
+		}
+	}
+	slices.SortFunc(check.TargetVersions, compareVersions)
+
+	if check.TargetVersion == "" && len(check.TargetVersions) > 0 {
+		check.TargetVersion = check.TargetVersions[len(check.TargetVersions)-1]
+	}
+	if args.TargetVersion != "" && !slices.Contains(check.TargetVersions, args.TargetVersion) {
+		return nil, fmt.Errorf("cluster %s cannot be upgraded to %s, target versions are %v", cluster.Metadata.Name, args.TargetVersion, check.TargetVersions)
+	}
+
+	templates, instances, err := clusterAddons(cce, cluster.Metadata.Id)
+	if err != nil {
+		return nil, err
+	}
+	check.Addons = addonUpgrades(templates, instances, cluster.Spec.Type, check.TargetVersion)
+	return check, nil
+}
+
+func getUpgradeInfo(cce *golangsdk.ServiceClient, clusterID string) (*upgradeInfo, error) {
+	var info upgradeInfo
+	_, err := cce.Get(cce.ServiceURL("clusters", clusterID, "upgradeinfo"), &info, &golangsdk.RequestOpts{
+		OkCodes:     []int{200},
+		MoreHeaders: map[string]string{"Content-Type": "application/json"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade information: %w", err)
+	}
+	return &info, nil
+}
+
+// addonUpgrades returns the installed add-ons that either do not support
+// the target version or have a newer version available. Without a target
+// version only the latter is checked.
+func addonUpgrades(templates []addons.AddonTemplate, instances []addons.Addon, clusterType, targetVersion string) []AddonUpgrade {
+	byName := map[string]addons.AddonTemplate{}
+	for _, t := range templates {
+		byName[t.Metadata.Name] = t
+	}
+
+	upgrades := []AddonUpgrade{}
+	for _, i := range instances {
+		upgrade := AddonUpgrade{
+			Name:           i.Spec.AddonTemplateName,
+			CurrentVersion: i.Spec.Version,
+		}
+
+		if targetVersion != "" {
+			// Instances missing the support information are not reported as
+			// blocking, since nothing is known about them.
+			current := i.Status.CurrentVersion
+			upgrade.Blocking = len(current.SupportVersions) > 0 && !supportsCluster(current, clusterType, targetVersion)
+			if template, ok := byName[upgrade.Name]; ok {
+				upgrade.LatestVersion = latestSupportedVersion(template, clusterType, targetVersion)
+			}
+		} else if len(i.Status.TargetVersions) > 0 {
+			upgrade.LatestVersion = slices.MaxFunc(i.Status.TargetVersions, compareVersions)
+		}
+
+		newer := upgrade.LatestVersion != "" && compareVersions(upgrade.LatestVersion, upgrade.CurrentVersion) > 0
+		if upgrade.Blocking || newer {
+			upgrades = append(upgrades, upgrade)
+		}
+	}
+
+	slices.SortFunc(upgrades, func(a, b AddonUpgrade) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return upgrades
+}
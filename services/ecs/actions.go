@@ -0,0 +1,280 @@
+package ecs
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"otc-cli/config"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/startstop"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ecs/v1/cloudservers"
+)
+
+const (
+	StatusActive       = "ACTIVE"
+	StatusShutoff      = "SHUTOFF"
+	StatusVerifyResize = "VERIFY_RESIZE"
+	StatusError        = "ERROR"
+)
+
+type ActionArgs struct {
+	Selector     ServerSelector
+	Parallelism  int
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+// ActionResult is the outcome of an action performed on a single server.
+type ActionResult struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type StopArgs struct {
+	ActionArgs
+	// Force powers the servers off instead of shutting them down gracefully.
+	Force bool
+}
+
+type RebootArgs struct {
+	ActionArgs
+	Hard bool
+}
+
+type ResizeArgs struct {
+	ActionArgs
+	// Flavor is the name or ID of the target flavor. It may be empty when
+	// only confirming or reverting a previous resize.
+	Flavor  string
+	Confirm bool
+	Revert  bool
+}
+
+// serverAction performs an action on a server. It returns the status the
+// server is expected to reach once the action completes.
+type serverAction func(clients *serviceClients, server servers.Server) (string, error)
+
+func StartServers(args ActionArgs) ([]ActionResult, error) {
+	return runAction(args, func(clients *serviceClients, server servers.Server) (string, error) {
+		if server.Status == StatusActive {
+			return StatusActive, nil
+		}
+		if err := startstop.Start(clients.compute, server.ID).ExtractErr(); err != nil {
+			return "", fmt.Errorf("failed to start server: %w", err)
+		}
+		return StatusActive, nil
+	})
+}
+
+func StopServers(args StopArgs) ([]ActionResult, error) {
+	return runAction(args.ActionArgs, func(clients *serviceClients, server servers.Server) (string, error) {
+		if server.Status == StatusShutoff {
+			return StatusShutoff, nil
+		}
+
+		var err error
+		if args.Force {
+			err = hardStop(clients.ecs, server.ID)
+		} else {
+			err = startstop.Stop(clients.compute, server.ID).ExtractErr()
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to stop server: %w", err)
+		}
+		return StatusShutoff, nil
+	})
+}
+
+func RebootServers(args RebootArgs) ([]ActionResult, error) {
+	method := servers.SoftReboot
+	if args.Hard {
+		method = servers.HardReboot
+	}
+
+	return runAction(args.ActionArgs, func(clients *serviceClients, server servers.Server) (string, error) {
+		err := servers.Reboot(clients.compute, server.ID, servers.RebootOpts{Type: method}).ExtractErr()
+		if err != nil {
+			return "", fmt.Errorf("failed to reboot server: %w", err)
+		}
+		return StatusActive, nil
+	})
+}
+
+// ResizeServers changes the flavor of the selected servers. A resized server
+// ends up in VERIFY_RESIZE until the resize is confirmed or reverted, which
+// can be done in the same call with Confirm or in a later one.
+func ResizeServers(args ResizeArgs) ([]ActionResult, error) {
+	if args.Flavor == "" && !args.Confirm && !args.Revert {
+		return nil, fmt.Errorf("a flavor is required unless confirming or reverting a resize")
+	}
+	if args.Confirm && args.Revert {
+		return nil, fmt.Errorf("confirm and revert are mutually exclusive")
+	}
+	if args.Flavor != "" && args.Revert {
+		return nil, fmt.Errorf("revert cannot be combined with a new flavor")
+	}
+
+	return runAction(args.ActionArgs, func(clients *serviceClients, server servers.Server) (string, error) {
+		if args.Flavor != "" {
+			if err := resize(clients, server, args.Flavor); err != nil {
+				return "", err
+			}
+			if !args.Confirm {
+				return StatusVerifyResize, nil
+			}
+			if _, err := waitForStatus(clients.compute, server.ID, StatusVerifyResize, args.Timeout); err != nil {
+				return "", err
+			}
+		}
+
+		if args.Revert {
+			if err := servers.RevertResize(clients.compute, server.ID).ExtractErr(); err != nil {
+				return "", fmt.Errorf("failed to revert resize: %w", err)
+			}
+			return StatusActive, nil
+		}
+
+		if err := servers.ConfirmResize(clients.compute, server.ID).ExtractErr(); err != nil {
+			return "", fmt.Errorf("failed to confirm resize: %w", err)
+		}
+		return StatusActive, nil
+	})
+}
+
+func resize(clients *serviceClients, server servers.Server, flavorName string) error {
+	cloudServer, err := cloudservers.Get(clients.ecs, server.ID).Extract()
+	if err != nil {
+		return fmt.Errorf("failed to get details of server: %w", err)
+	}
+
+	flavor, err := findFlavor(clients.ecs, flavorName, cloudServer.AvailabilityZone)
+	if err != nil {
+		return err
+	}
+	if flavor.ID == cloudServer.Flavor.ID {
+		return fmt.Errorf("server already uses flavor %s", flavor.Name)
+	}
+
+	err = servers.Resize(clients.compute, server.ID, servers.ResizeOpts{FlavorRef: flavor.ID}).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("failed to resize server: %w", err)
+	}
+	return nil
+}
+
+// hardStop powers a server off through the ECS batch action API, since the
+// Nova stop action has no forced variant.
+func hardStop(ecs *golangsdk.ServiceClient, serverID string) error {
+	body := map[string]interface{}{
+		"os-stop": map[string]interface{}{
+			"type": "HARD",
+			"servers": []map[string]string{
+				{"id": serverID},
+			},
+		},
+	}
+
+	_, err := ecs.Post(ecs.ServiceURL("cloudservers", "action"), body, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return err
+}
+
+// Failed reports whether the result carries an error.
+func (r ActionResult) Failed() bool {
+	return r.Error != ""
+}
+
+// runAction resolves the selected servers and applies the action to them
+// using a bounded pool of workers. Results are returned in resolution order.
+func runAction(args ActionArgs, action serverAction) ([]ActionResult, error) {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	serverList, err := resolveServers(clients, args.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	parallelism := args.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([]ActionResult, len(serverList))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(parallelism, len(serverList)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = applyAction(clients, serverList[i], action, args)
+			}
+		}()
+	}
+
+	for i := range serverList {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
+
+func applyAction(clients *serviceClients, server servers.Server, action serverAction, args ActionArgs) ActionResult {
+	result := ActionResult{
+		ID:     server.ID,
+		Name:   server.Name,
+		Status: server.Status,
+	}
+
+	target, err := action(clients, server)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if !args.Wait {
+		return result
+	}
+
+	result.Status, err = waitForStatus(clients.compute, server.ID, target, args.Timeout)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// waitForStatus polls the server until it reaches the given status, enters
+// the ERROR state or the timeout expires. It returns the last seen status.
+func waitForStatus(compute *golangsdk.ServiceClient, id string, status string, timeout time.Duration) (string, error) {
+	var current string
+	err := golangsdk.WaitFor(int(timeout.Seconds()), func() (bool, error) {
+		server, err := servers.Get(compute, id).Extract()
+		if err != nil {
+			return false, err
+		}
+
+		current = server.Status
+		if current == StatusError && status != StatusError {
+			return false, fmt.Errorf("server entered %s state: %s", StatusError, server.Fault.Message)
+		}
+		return current == status, nil
+	})
+	if err != nil {
+		return current, fmt.Errorf("waiting for status %s: %w", status, err)
+	}
+
+	return current, nil
+}
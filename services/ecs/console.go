@@ -0,0 +1,126 @@
+package ecs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+)
+
+type ConsoleLogArgs struct {
+	Selector ServerSelector
+	// Lines limits the output to the last lines of the log, 0 means everything.
+	Lines int
+	// Follow keeps polling the log and prints new lines as they appear.
+	Follow       bool
+	Interval     time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+// ConsoleLog writes the serial console log of the selected server to w.
+func ConsoleLog(args ConsoleLogArgs, w io.Writer) error {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return err
+	}
+
+	server, err := resolveServer(clients, args.Selector)
+	if err != nil {
+		return err
+	}
+
+	if !args.Follow {
+		output, err := consoleOutput(clients, server, args.Lines)
+		if err != nil {
+			return err
+		}
+		if output = strings.TrimRight(output, "\n"); output != "" {
+			_, err = fmt.Fprintln(w, output)
+		}
+		return err
+	}
+
+	// The console log API only returns the last lines of the log, without
+	// their position in it, so following reads the whole log on every poll
+	// and counts the lines already printed.
+	printed := 0
+	for first := true; ; first = false {
+		output, err := consoleOutput(clients, server, 0)
+		if err != nil {
+			return err
+		}
+
+		var lines []string
+		lines, printed = newLines(output, printed)
+		if first && args.Lines > 0 && len(lines) > args.Lines {
+			lines = lines[len(lines)-args.Lines:]
+		}
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+
+		time.Sleep(args.Interval)
+	}
+}
+
+func consoleOutput(clients *serviceClients, server *servers.Server, lines int) (string, error) {
+	output, err := servers.ShowConsoleOutput(clients.compute, server.ID, servers.ShowConsoleOutputOpts{Length: lines}).Extract()
+	if err != nil {
+		return "", fmt.Errorf("failed to get console log of server %s: %w", server.Name, err)
+	}
+	return output, nil
+}
+
+// newLines returns the complete lines of the log after the first printed
+// ones, together with the number of complete lines. A last line without
+// newline is held back until a later poll completes it. When the log has
+// fewer lines than were printed, it was truncated (e.g. by a rebuild) and is
+// returned from the start.
+func newLines(log string, printed int) ([]string, int) {
+	var lines []string
+	if i := strings.LastIndex(log, "\n"); i >= 0 {
+		lines = strings.Split(log[:i], "\n")
+	}
+	if printed > len(lines) {
+		printed = 0
+	}
+	return lines[printed:], len(lines)
+}
+
+// ConsoleURL returns the URL of the remote (noVNC) console of the selected server.
+func ConsoleURL(selector ServerSelector, commonConfig *config.CommonConfig) (string, error) {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return "", err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return "", err
+	}
+
+	body := map[string]interface{}{
+		"remote_console": map[string]string{
+			"protocol": "vnc",
+			"type":     "novnc",
+		},
+	}
+	var response struct {
+		RemoteConsole struct {
+			URL string `json:"url"`
+		} `json:"remote_console"`
+	}
+
+	url := clients.ecs.ServiceURL("cloudservers", server.ID, "remote_console")
+	if _, err := clients.ecs.Post(url, body, &response, nil); err != nil {
+		return "", fmt.Errorf("failed to get console URL of server %s: %w", server.Name, err)
+	}
+
+	return response.RemoteConsole.URL, nil
+}
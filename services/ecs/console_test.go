@@ -0,0 +1,34 @@
+package ecs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		log       string
+		printed   int
+		want      []string
+		wantTotal int
+	}{
+		{name: "empty", log: "", printed: 0, want: nil, wantTotal: 0},
+		{name: "first poll", log: "a\nb\n", printed: 0, want: []string{"a", "b"}, wantTotal: 2},
+		{name: "partial line held back", log: "a\nb\nc", printed: 0, want: []string{"a", "b"}, wantTotal: 2},
+		{name: "only a partial line", log: "boot", printed: 0, want: nil, wantTotal: 0},
+		{name: "partial line completed", log: "a\nb\nc\n", printed: 2, want: []string{"c"}, wantTotal: 3},
+		{name: "nothing new", log: "a\nb\n", printed: 2, want: []string{}, wantTotal: 2},
+		{name: "repeated lines", log: "x\nx\nx\nx\n", printed: 2, want: []string{"x", "x"}, wantTotal: 4},
+		{name: "truncated log", log: "c\n", printed: 3, want: []string{"c"}, wantTotal: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total := newLines(tt.log, tt.printed)
+			if !slices.Equal(got, tt.want) || total != tt.wantTotal {
+				t.Errorf("newLines(%q, %d) = %q, %d, want %q, %d", tt.log, tt.printed, got, total, tt.want, tt.wantTotal)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package ecs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"otc-cli/config"
+	"otc-cli/errs"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ecs/v1/cloudservers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ims/v2/images"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/subnets"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v1/vpcs"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/opentelekomcloud/gophertelekomcloud/pagination"
+	"gopkg.in/yaml.v2"
+)
+
+const defaultVolumeType = "SSD"
+
+// CreateSpec describes a server to create. References (flavor, image, VPC,
+// subnet, security groups) can be given by name or ID.
+type CreateSpec struct {
+	Name             string            `yaml:"name"`
+	Flavor           string            `yaml:"flavor"`
+	Image            string            `yaml:"image"`
+	VPC              string            `yaml:"vpc,omitempty"`
+	Subnet           string            `yaml:"subnet"`
+	SecurityGroups   []string          `yaml:"security_groups,omitempty"`
+	KeyPair          string            `yaml:"key_pair,omitempty"`
+	AvailabilityZone string            `yaml:"availability_zone,omitempty"`
+	VolumeType       string            `yaml:"volume_type,omitempty"`
+	RootVolumeSize   int               `yaml:"root_volume_size,omitempty"`
+	DataVolumeSizes  []int             `yaml:"data_volume_sizes,omitempty"`
+	UserDataFile     string            `yaml:"user_data_file,omitempty"`
+	Tags             map[string]string `yaml:"tags,omitempty"`
+}
+
+type CreateArgs struct {
+	// Spec holds values given on the command line. They take precedence over
+	// the values loaded from SpecFile.
+	Spec         CreateSpec
+	SpecFile     string
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+type CreateResult struct {
+	JobID string
+	// Server is only set when waiting for the creation to finish.
+	Server *servers.Server
+}
+
+// LoadCreateSpec reads a server spec from a YAML file. A relative user data
+// path is resolved against the directory of the spec file.
+func LoadCreateSpec(path string) (CreateSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CreateSpec{}, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	var spec CreateSpec
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return CreateSpec{}, fmt.Errorf("failed to parse spec file: %w", err)
+	}
+
+	if spec.UserDataFile != "" && !filepath.IsAbs(spec.UserDataFile) {
+		spec.UserDataFile = filepath.Join(filepath.Dir(path), spec.UserDataFile)
+	}
+
+	return spec, nil
+}
+
+// setDefaults fills every field left empty with the value from other.
+func (spec *CreateSpec) setDefaults(other CreateSpec) {
+	config.SetIfEmpty(&spec.Name, other.Name)
+	config.SetIfEmpty(&spec.Flavor, other.Flavor)
+	config.SetIfEmpty(&spec.Image, other.Image)
+	config.SetIfEmpty(&spec.VPC, other.VPC)
+	config.SetIfEmpty(&spec.Subnet, other.Subnet)
+	config.SetIfEmpty(&spec.KeyPair, other.KeyPair)
+	config.SetIfEmpty(&spec.AvailabilityZone, other.AvailabilityZone)
+	config.SetIfEmpty(&spec.VolumeType, other.VolumeType)
+	config.SetIfEmpty(&spec.UserDataFile, other.UserDataFile)
+	config.SetIfZero(&spec.RootVolumeSize, other.RootVolumeSize)
+
+	if len(spec.SecurityGroups) == 0 {
+		spec.SecurityGroups = other.SecurityGroups
+	}
+	if len(spec.DataVolumeSizes) == 0 {
+		spec.DataVolumeSizes = other.DataVolumeSizes
+	}
+	if len(spec.Tags) == 0 {
+		spec.Tags = other.Tags
+	}
+}
+
+func (spec CreateSpec) validate() error {
+	missing := []string{}
+	if spec.Name == "" {
+		missing = append(missing, "name")
+	}
+	if spec.Flavor == "" {
+		missing = append(missing, "flavor")
+	}
+	if spec.Image == "" {
+		missing = append(missing, "image")
+	}
+	if spec.Subnet == "" {
+		missing = append(missing, "subnet")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required fields: %v", missing)
+	}
+	return nil
+}
+
+func Create(args CreateArgs) (*CreateResult, error) {
+	spec := args.Spec
+	if args.SpecFile != "" {
+		fileSpec, err := LoadCreateSpec(args.SpecFile)
+		if err != nil {
+			return nil, err
+		}
+		spec.setDefaults(fileSpec)
+	}
+	config.SetIfEmpty(&spec.VolumeType, defaultVolumeType)
+
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := buildCreateOpts(clients, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := cloudservers.Create(clients.ecs, opts).ExtractJobResponse()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server %s: %w", spec.Name, err)
+	}
+
+	result := &CreateResult{JobID: job.JobID}
+	if !args.Wait {
+		return result, nil
+	}
+
+	if err := cloudservers.WaitForJobSuccess(clients.ecs, int(args.Timeout.Seconds()), job.JobID); err != nil {
+		return result, fmt.Errorf("failed waiting for server %s: %w", spec.Name, err)
+	}
+
+	serverID, err := cloudservers.GetJobEntity(clients.ecs, job.JobID, "server_id")
+	if err != nil {
+		return result, fmt.Errorf("failed to get ID of server %s: %w", spec.Name, err)
+	}
+
+	result.Server, err = servers.Get(clients.compute, serverID.(string)).Extract()
+	if err != nil {
+		return result, fmt.Errorf("failed to get server %s: %w", spec.Name, err)
+	}
+
+	return result, nil
+}
+
+// buildCreateOpts resolves all references of the spec, so that mistakes are
+// reported before anything is submitted.
+func buildCreateOpts(clients *serviceClients, spec CreateSpec) (cloudservers.CreateOpts, error) {
+	opts := cloudservers.CreateOpts{
+		Name:    spec.Name,
+		KeyName: spec.KeyPair,
+		RootVolume: cloudservers.RootVolume{
+			VolumeType: spec.VolumeType,
+			Size:       spec.RootVolumeSize,
+		},
+	}
+
+	if spec.AvailabilityZone != "" {
+		opts.AvailabilityZone = &spec.AvailabilityZone
+	}
+
+	flavor, err := findFlavor(clients.ecs, spec.Flavor, spec.AvailabilityZone)
+	if err != nil {
+		return opts, err
+	}
+	opts.FlavorRef = flavor.ID
+
+	ims, err := openstack.NewIMSV2(clients.provider, clients.endpointOpts)
+	if err != nil {
+		return opts, fmt.Errorf("failed to create IMS client: %w", err)
+	}
+	image, err := findImage(ims, spec.Image)
+	if err != nil {
+		return opts, err
+	}
+	if spec.RootVolumeSize > 0 && spec.RootVolumeSize < image.MinDisk {
+		return opts, fmt.Errorf("root volume of %d GB is smaller than the %d GB required by image %s", spec.RootVolumeSize, image.MinDisk, image.Name)
+	}
+	opts.ImageRef = image.Id
+
+	vpcClient, err := openstack.NewNetworkV1(clients.provider, clients.endpointOpts)
+	if err != nil {
+		return opts, fmt.Errorf("failed to create VPC client: %w", err)
+	}
+	vpcID := ""
+	if spec.VPC != "" {
+		vpc, err := findVPC(vpcClient, spec.VPC)
+		if err != nil {
+			return opts, err
+		}
+		vpcID = vpc.ID
+	}
+	subnet, err := findSubnet(vpcClient, vpcID, spec.Subnet)
+	if err != nil {
+		return opts, err
+	}
+	opts.VpcId = subnet.VpcID
+	opts.Nics = []cloudservers.Nic{{SubnetId: subnet.ID}}
+
+	if len(spec.SecurityGroups) > 0 {
+		network, err := openstack.NewNetworkV2(clients.provider, clients.endpointOpts)
+		if err != nil {
+			return opts, fmt.Errorf("failed to create Network client: %w", err)
+		}
+		for _, name := range spec.SecurityGroups {
+			group, err := findSecurityGroup(network, name)
+			if err != nil {
+				return opts, err
+			}
+			opts.SecurityGroups = append(opts.SecurityGroups, cloudservers.SecurityGroup{ID: group.ID})
+		}
+	}
+
+	if spec.KeyPair != "" {
+		if _, err := keypairs.Get(clients.compute, spec.KeyPair).Extract(); err != nil {
+			return opts, fmt.Errorf("key pair %s not found: %w", spec.KeyPair, err)
+		}
+	}
+
+	for _, size := range spec.DataVolumeSizes {
+		opts.DataVolumes = append(opts.DataVolumes, cloudservers.DataVolume{
+			VolumeType: spec.VolumeType,
+			Size:       size,
+		})
+	}
+
+	if spec.UserDataFile != "" {
+		opts.UserData, err = os.ReadFile(spec.UserDataFile)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read user data: %w", err)
+		}
+	}
+
+	for key, value := range spec.Tags {
+		opts.ServerTags = append(opts.ServerTags, cloudservers.ServerTags{Key: key, Value: value})
+	}
+
+	return opts, nil
+}
+
+func findImage(ims *golangsdk.ServiceClient, nameOrID string) (*images.ImageInfo, error) {
+	imageList, err := images.ListImages(ims, images.ListImagesOpts{Name: nameOrID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	if len(imageList) == 0 {
+		imageList, err = images.ListImages(ims, images.ListImagesOpts{Id: nameOrID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list images: %w", err)
+		}
+	}
+
+	switch len(imageList) {
+	case 0:
+		return nil, &errs.NotFoundError{Resource: "image", Name: nameOrID}
+	case 1:
+		return &imageList[0], nil
+	default:
+		return nil, &errs.AmbiguousError{Resource: "image", Name: nameOrID, Count: len(imageList), Hint: "use the image ID"}
+	}
+}
+
+func findVPC(vpcClient *golangsdk.ServiceClient, nameOrID string) (*vpcs.Vpc, error) {
+	vpcList, err := vpcs.List(vpcClient, vpcs.ListOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VPCs: %w", err)
+	}
+
+	var matches []vpcs.Vpc
+	for _, vpc := range vpcList {
+		if vpc.ID == nameOrID || vpc.Name == nameOrID {
+			matches = append(matches, vpc)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &errs.NotFoundError{Resource: "VPC", Name: nameOrID}
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &errs.AmbiguousError{Resource: "VPC", Name: nameOrID, Count: len(matches), Hint: "use the VPC ID"}
+	}
+}
+
+func findSubnet(vpcClient *golangsdk.ServiceClient, vpcID string, nameOrID string) (*subnets.Subnet, error) {
+	subnetList, err := subnets.List(vpcClient, subnets.ListOpts{VpcID: vpcID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %w", err)
+	}
+
+	var matches []subnets.Subnet
+	for _, subnet := range subnetList {
+		if subnet.ID == nameOrID || subnet.SubnetID == nameOrID {
+			return &subnet, nil
+		}
+		if subnet.Name == nameOrID {
+			matches = append(matches, subnet)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &errs.NotFoundError{Resource: "subnet", Name: nameOrID}
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &errs.AmbiguousError{Resource: "subnet", Name: nameOrID, Count: len(matches), Hint: "pass the VPC or use the subnet ID"}
+	}
+}
+
+func findSecurityGroup(network *golangsdk.ServiceClient, nameOrID string) (*groups.SecGroup, error) {
+	var matches []groups.SecGroup
+	err := groups.List(network, groups.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		groupList, err := groups.ExtractGroups(page)
+		if err != nil {
+			return false, err
+		}
+		for _, group := range groupList {
+			if group.ID == nameOrID || group.Name == nameOrID {
+				matches = append(matches, group)
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups: %w", err)
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &errs.NotFoundError{Resource: "security group", Name: nameOrID}
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &errs.AmbiguousError{Resource: "security group", Name: nameOrID, Count: len(matches), Hint: "use the group ID"}
+	}
+}
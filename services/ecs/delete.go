@@ -0,0 +1,166 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"otc-cli/config"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ecs/v1/cloudservers"
+)
+
+const DefaultProtectionTag = "protected"
+
+// ErrDeleteAborted is returned when the deletion was not confirmed.
+var ErrDeleteAborted = errors.New("deletion aborted")
+
+type DeleteArgs struct {
+	Selector ServerSelector
+	// ProtectionTag is the tag key marking servers that must not be deleted.
+	ProtectionTag string
+	// DeleteVolumes also deletes the data volumes attached to the servers.
+	DeleteVolumes bool
+	// ReleaseEIPs also releases the elastic IPs bound to the servers.
+	ReleaseEIPs bool
+	Timeout     time.Duration
+	// Confirm is called with the servers about to be deleted. Deletion only
+	// proceeds when it returns true.
+	Confirm      func(plan []DeletePlanItem) (bool, error)
+	CommonConfig *config.CommonConfig
+}
+
+// DeletePlanItem describes a server about to be deleted together with the
+// resources removed along with it.
+type DeletePlanItem struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Volumes []string `json:"volumes"`
+	EIPs    []string `json:"eips"`
+}
+
+// ProtectedError is returned when some selected servers carry the protection tag.
+type ProtectedError struct {
+	Tag     string
+	Servers []string
+}
+
+func (e *ProtectedError) Error() string {
+	return fmt.Sprintf("refusing to delete servers tagged '%s': %s", e.Tag, strings.Join(e.Servers, ", "))
+}
+
+func Delete(args DeleteArgs) error {
+	config.SetIfEmpty(&args.ProtectionTag, DefaultProtectionTag)
+
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return err
+	}
+
+	serverList, err := resolveServers(clients, args.Selector)
+	if err != nil {
+		return err
+	}
+
+	plan, err := planDelete(clients, serverList, args)
+	if err != nil {
+		return err
+	}
+
+	if args.Confirm != nil {
+		confirmed, err := args.Confirm(plan)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return ErrDeleteAborted
+		}
+	}
+
+	opts := cloudservers.DeleteOpts{
+		DeletePublicIP: args.ReleaseEIPs,
+		DeleteVolume:   args.DeleteVolumes,
+	}
+	for _, item := range plan {
+		opts.Servers = append(opts.Servers, cloudservers.Server{Id: item.ID})
+	}
+
+	job, err := cloudservers.Delete(clients.ecs, opts).ExtractJobResponse()
+	if err != nil {
+		return fmt.Errorf("failed to delete servers: %w", err)
+	}
+
+	if err := cloudservers.WaitForJobSuccess(clients.ecs, int(args.Timeout.Seconds()), job.JobID); err != nil {
+		return fmt.Errorf("failed waiting for deletion (job %s): %w", job.JobID, err)
+	}
+
+	return waitForDeletion(clients.compute, plan, args.Timeout)
+}
+
+func planDelete(clients *serviceClients, serverList []servers.Server, args DeleteArgs) ([]DeletePlanItem, error) {
+	var plan []DeletePlanItem
+	var protected []string
+
+	for _, server := range serverList {
+		tags, err := getServerTags(clients, server.ID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := tags[args.ProtectionTag]; ok {
+			protected = append(protected, server.Name)
+			continue
+		}
+
+		item := DeletePlanItem{
+			ID:     server.ID,
+			Name:   server.Name,
+			Status: server.Status,
+		}
+		for _, volume := range server.VolumesAttached {
+			if volume.DeleteOnTermination || args.DeleteVolumes {
+				item.Volumes = append(item.Volumes, volume.ID)
+			}
+		}
+		if args.ReleaseEIPs {
+			for _, address := range Addresses(server) {
+				if address.Type == AddressFloating {
+					item.EIPs = append(item.EIPs, address.Addr)
+				}
+			}
+		}
+		plan = append(plan, item)
+	}
+
+	if len(protected) > 0 {
+		return nil, &ProtectedError{Tag: args.ProtectionTag, Servers: protected}
+	}
+
+	return plan, nil
+}
+
+// waitForDeletion polls until none of the deleted servers can be found.
+func waitForDeletion(compute *golangsdk.ServiceClient, plan []DeletePlanItem, timeout time.Duration) error {
+	for _, item := range plan {
+		err := golangsdk.WaitFor(int(timeout.Seconds()), func() (bool, error) {
+			server, err := servers.Get(compute, item.ID).Extract()
+			if err == nil {
+				return server.Status == "DELETED", nil
+			}
+
+			var notFound golangsdk.ErrDefault404
+			if errors.As(err, &notFound) {
+				return true, nil
+			}
+			return false, err
+		})
+		if err != nil {
+			return fmt.Errorf("failed waiting for server %s to be deleted: %w", item.Name, err)
+		}
+	}
+
+	return nil
+}
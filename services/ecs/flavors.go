@@ -0,0 +1,183 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"otc-cli/config"
+	"otc-cli/errs"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+)
+
+// Flavor is an ECS flavor as returned by the OTC flavor API, which unlike
+// the Nova API reports where the flavor can be used.
+type Flavor struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	VCPUs      string           `json:"vcpus"`
+	RAM        int              `json:"ram"`
+	Disk       string           `json:"disk"`
+	ExtraSpecs FlavorExtraSpecs `json:"os_extra_specs"`
+	// ConfiguredPrice is the price from the user's price list in clouds.yaml,
+	// nil when the flavor is not listed there. It is not checked against the
+	// OTC price list.
+	ConfiguredPrice *FlavorPrice `json:"configured_price,omitempty"`
+}
+
+// FlavorPrice is the hourly price of a flavor.
+type FlavorPrice struct {
+	PerHour  float64 `json:"per_hour"`
+	Currency string  `json:"currency,omitempty"`
+}
+
+type FlavorExtraSpecs struct {
+	PerformanceType string `json:"ecs:performancetype"`
+	Generation      string `json:"ecs:generation"`
+	ResourceType    string `json:"resource_type"`
+	// OperationStatus is the default sale status of the flavor.
+	OperationStatus string `json:"cond:operation:status"`
+	// OperationAZ overrides the sale status per AZ, e.g. "eu-de-01(normal),eu-de-02(sellout)".
+	OperationAZ string `json:"cond:operation:az"`
+}
+
+type listFlavorsOpts struct {
+	AvailabilityZone string `q:"availability_zone"`
+}
+
+func listFlavors(ecs *golangsdk.ServiceClient, availabilityZone string) ([]Flavor, error) {
+	query, err := golangsdk.BuildQueryString(listFlavorsOpts{AvailabilityZone: availabilityZone})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Flavors []Flavor `json:"flavors"`
+	}
+	_, err = ecs.Get(ecs.ServiceURL("cloudservers", "flavors")+query.String(), &response, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flavors: %w", err)
+	}
+
+	return response.Flavors, nil
+}
+
+type ListFlavorsArgs struct {
+	// AvailabilityZone limits the result to flavors usable in the zone.
+	AvailabilityZone string
+	MinVCPUs         int
+	// MinRAM is the minimum memory in GB.
+	MinRAM int
+	// Family limits the result to a flavor family such as s3 or c4.
+	Family string
+	// Prices are hourly prices by flavor name attached as price hints.
+	Prices        map[string]float64
+	PriceCurrency string
+	CommonConfig  *config.CommonConfig
+}
+
+// ListFlavors returns the flavors ordered by name.
+func ListFlavors(args ListFlavorsArgs) ([]Flavor, error) {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	flavorList, err := listFlavors(clients.ecs, args.AvailabilityZone)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Flavor, 0, len(flavorList))
+	for _, flavor := range flavorList {
+		if args.AvailabilityZone != "" && !flavor.AvailableIn(args.AvailabilityZone) {
+			continue
+		}
+		if flavor.VCPUCount() < args.MinVCPUs || flavor.RAM < args.MinRAM*1024 {
+			continue
+		}
+		if args.Family != "" && flavor.Family() != args.Family {
+			continue
+		}
+		if price, ok := args.Prices[flavor.Name]; ok {
+			flavor.ConfiguredPrice = &FlavorPrice{PerHour: price, Currency: args.PriceCurrency}
+		}
+		result = append(result, flavor)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// VCPUCount returns the number of vCPUs, which the API reports as a string.
+func (f Flavor) VCPUCount() int {
+	count, _ := strconv.Atoi(f.VCPUs)
+	return count
+}
+
+// Family returns the flavor family, the name part before the first dot
+// (s3 for s3.xlarge.4).
+func (f Flavor) Family() string {
+	family, _, _ := strings.Cut(f.Name, ".")
+	return family
+}
+
+// AZStatuses returns the sale status per availability zone for the zones
+// with an explicit status.
+func (f Flavor) AZStatuses() map[string]string {
+	statuses := map[string]string{}
+	for _, entry := range strings.Split(f.ExtraSpecs.OperationAZ, ",") {
+		az, status, ok := strings.Cut(strings.TrimSpace(entry), "(")
+		if ok && az != "" {
+			statuses[az] = strings.TrimSuffix(status, ")")
+		}
+	}
+	return statuses
+}
+
+// AZStatus returns the sale status of the flavor in the given availability
+// zone, falling back to the flavor-wide status.
+func (f Flavor) AZStatus(availabilityZone string) string {
+	if status, ok := f.AZStatuses()[availabilityZone]; ok {
+		return status
+	}
+	return f.ExtraSpecs.OperationStatus
+}
+
+// AvailableIn reports whether new servers can use the flavor in the given
+// availability zone.
+func (f Flavor) AvailableIn(availabilityZone string) bool {
+	switch f.AZStatus(availabilityZone) {
+	case "abandon", "sellout":
+		return false
+	default:
+		return true
+	}
+}
+
+// findFlavor looks up a flavor by ID or name and checks that it can be used
+// in the given availability zone. A flavor that exists but is not sold there
+// is reported as not found as well.
+func findFlavor(ecs *golangsdk.ServiceClient, nameOrID string, availabilityZone string) (*Flavor, error) {
+	flavorList, err := listFlavors(ecs, availabilityZone)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, flavor := range flavorList {
+		if flavor.ID != nameOrID && flavor.Name != nameOrID {
+			continue
+		}
+		if !flavor.AvailableIn(availabilityZone) {
+			notFound := &errs.NotFoundError{Resource: "flavor", Name: nameOrID}
+			return nil, fmt.Errorf("%w: not available in %s (%s)", notFound, availabilityZone, flavor.AZStatus(availabilityZone))
+		}
+		return &flavor, nil
+	}
+
+	return nil, &errs.NotFoundError{Resource: "flavor", Name: nameOrID}
+}
@@ -0,0 +1,127 @@
+package ecs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+)
+
+// UserKey is the tag or metadata key overriding the login user of a server.
+const UserKey = "ssh_user"
+
+// InventoryHost is a server as it appears in an SSH config or Ansible inventory.
+type InventoryHost struct {
+	Name    string   `json:"name" yaml:"name"`
+	Address string   `json:"address" yaml:"address"`
+	User    string   `json:"user,omitempty" yaml:"user,omitempty"`
+	KeyName string   `json:"key_name,omitempty" yaml:"key_name,omitempty"`
+	Groups  []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+type InventoryArgs struct {
+	ListArgs
+	// Address is the preferred address type, AddressFloating or AddressFixed.
+	Address string
+	// User is used for servers whose user is neither tagged nor derivable
+	// from the image.
+	User string
+	// GroupBy lists tag or metadata keys; every server joins the group
+	// "<key>_<value>" for each of them.
+	GroupBy []string
+}
+
+// defaultImageUsers maps image name prefixes to the default cloud user.
+var defaultImageUsers = []struct {
+	prefix string
+	user   string
+}{
+	{"ubuntu", "ubuntu"},
+	{"debian", "debian"},
+	{"centos", "centos"},
+	{"fedora", "fedora"},
+	{"rocky", "rocky"},
+	{"alma", "almalinux"},
+}
+
+var invalidGroupChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Inventory lists the servers as inventory hosts. Servers without a usable
+// address, e.g. still building, are skipped and reported as warnings.
+func Inventory(args InventoryArgs) ([]InventoryHost, []error, error) {
+	config.SetIfEmpty(&args.Address, AddressFloating)
+	if args.Address != AddressFloating && args.Address != AddressFixed {
+		return nil, nil, fmt.Errorf("unknown address type '%s', expected %s or %s", args.Address, AddressFloating, AddressFixed)
+	}
+
+	args.WithTags = true
+	// the inventory does not use flavor and image names, so failing to look
+	// them up is not worth a warning
+	serverList, _, err := List(args.ListArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hosts := make([]InventoryHost, 0, len(serverList))
+	var warnings []error
+	for _, server := range serverList {
+		address, err := PreferredAddress(server.Server, args.Address)
+		if err != nil {
+			warnings = append(warnings, fmt.Errorf("skipping server %s: %w", server.Name, err))
+			continue
+		}
+
+		hosts = append(hosts, InventoryHost{
+			Name:    server.Name,
+			Address: address,
+			User:    serverUser(server.Server, server.Tags, args.User),
+			KeyName: server.KeyName,
+			Groups:  serverGroups(server.Server, server.Tags, args.GroupBy),
+		})
+	}
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Name < hosts[j].Name
+	})
+	return hosts, warnings, nil
+}
+
+// serverUser picks the login user from the ssh_user tag or metadata, then
+// from the image name recorded in the server metadata.
+func serverUser(server servers.Server, tags map[string]string, fallback string) string {
+	if user := tags[UserKey]; user != "" {
+		return user
+	}
+	if user := server.Metadata[UserKey]; user != "" {
+		return user
+	}
+
+	image := strings.ToLower(server.Metadata["image_name"])
+	for _, candidate := range defaultImageUsers {
+		if strings.HasPrefix(image, candidate.prefix) {
+			return candidate.user
+		}
+	}
+
+	return fallback
+}
+
+func serverGroups(server servers.Server, tags map[string]string, groupBy []string) []string {
+	var groups []string
+	for _, key := range groupBy {
+		value, ok := tags[key]
+		if !ok {
+			value, ok = server.Metadata[key]
+		}
+		if !ok || value == "" {
+			continue
+		}
+
+		groups = append(groups, invalidGroupChars.ReplaceAllString(fmt.Sprintf("%s_%s", key, value), "_"))
+	}
+	return groups
+}
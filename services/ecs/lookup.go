@@ -0,0 +1,117 @@
+package ecs
+
+import (
+	"fmt"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ims/v2/images"
+)
+
+// FlavorID returns the flavor ID of a server, or an empty string when the
+// API did not report one.
+func FlavorID(s servers.Server) string {
+	id, _ := s.Flavor["id"].(string)
+	return id
+}
+
+// ImageID returns the image ID of a server. It is empty for servers booted
+// from a volume.
+func ImageID(s servers.Server) string {
+	id, _ := s.Image["id"].(string)
+	return id
+}
+
+// String formats the flavor as name with its size.
+func (f Flavor) String() string {
+	return fmt.Sprintf("%s (%s vCPU, %d MB RAM)", f.Name, f.VCPUs, f.RAM)
+}
+
+// lookupCache resolves flavor and image IDs to their details, querying every
+// ID at most once. A failed lookup is recorded as a warning and not retried,
+// leaving the raw IDs in place.
+type lookupCache struct {
+	clients       *serviceClients
+	flavors       map[string]Flavor
+	images        map[string]*images.ImageInfo
+	flavorsFailed bool
+	imagesFailed  bool
+	warnings      []error
+}
+
+func newLookupCache(clients *serviceClients) *lookupCache {
+	return &lookupCache{
+		clients: clients,
+		images:  map[string]*images.ImageInfo{},
+	}
+}
+
+// flavor returns the flavor with the given ID, or nil when it is unknown.
+func (c *lookupCache) flavor(id string) (*Flavor, error) {
+	if c.flavors == nil {
+		flavorList, err := listFlavors(c.clients.ecs, "")
+		if err != nil {
+			return nil, err
+		}
+
+		c.flavors = make(map[string]Flavor, len(flavorList))
+		for _, flavor := range flavorList {
+			c.flavors[flavor.ID] = flavor
+		}
+	}
+
+	flavor, ok := c.flavors[id]
+	if !ok {
+		return nil, nil
+	}
+	return &flavor, nil
+}
+
+// image returns the image with the given ID, or nil when it was deleted or
+// is not visible to the project.
+func (c *lookupCache) image(id string) (*images.ImageInfo, error) {
+	if image, ok := c.images[id]; ok {
+		return image, nil
+	}
+
+	ims, err := openstack.NewIMSV2(c.clients.provider, c.clients.endpointOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IMS client: %w", err)
+	}
+
+	imageList, err := images.ListImages(ims, images.ListImagesOpts{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image %s: %w", id, err)
+	}
+
+	var image *images.ImageInfo
+	if len(imageList) > 0 {
+		image = &imageList[0]
+	}
+	c.images[id] = image
+	return image, nil
+}
+
+// resolveNames fills in the flavor details and image name of a server where
+// they can be looked up.
+func (c *lookupCache) resolveNames(server *Server) {
+	if !c.flavorsFailed {
+		flavor, err := c.flavor(FlavorID(server.Server))
+		if err != nil {
+			c.flavorsFailed = true
+			c.warnings = append(c.warnings, fmt.Errorf("%w, showing flavor IDs", err))
+		}
+		server.FlavorDetails = flavor
+	}
+
+	if imageID := ImageID(server.Server); imageID != "" && !c.imagesFailed {
+		image, err := c.image(imageID)
+		if err != nil {
+			c.imagesFailed = true
+			c.warnings = append(c.warnings, fmt.Errorf("%w, showing image IDs", err))
+		}
+		if image != nil {
+			server.ImageName = image.Name
+		}
+	}
+}
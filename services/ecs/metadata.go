@@ -0,0 +1,79 @@
+package ecs
+
+import (
+	"fmt"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+)
+
+// GetMetadata returns the metadata of a server, limited to keys when given.
+func GetMetadata(selector ServerSelector, keys []string, commonConfig *config.CommonConfig) ([]KeyValue, error) {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := servers.Metadata(clients.compute, server.ID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata of server %s: %w", server.Name, err)
+	}
+
+	if len(keys) == 0 {
+		return sortedKeyValues(metadata), nil
+	}
+
+	selected := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, ok := metadata[key]
+		if !ok {
+			return nil, fmt.Errorf("server %s has no metadata '%s'", server.Name, key)
+		}
+		selected[key] = value
+	}
+	return sortedKeyValues(selected), nil
+}
+
+// SetMetadata creates or updates metadata entries, keeping the other ones.
+func SetMetadata(selector ServerSelector, metadata map[string]string, commonConfig *config.CommonConfig) error {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return err
+	}
+
+	_, err = servers.UpdateMetadata(clients.compute, server.ID, servers.MetadataOpts(metadata)).Extract()
+	if err != nil {
+		return fmt.Errorf("failed to set metadata of server %s: %w", server.Name, err)
+	}
+	return nil
+}
+
+func UnsetMetadata(selector ServerSelector, keys []string, commonConfig *config.CommonConfig) error {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := servers.DeleteMetadatum(clients.compute, server.ID, key).ExtractErr(); err != nil {
+			return fmt.Errorf("failed to unset metadata '%s' of server %s: %w", key, server.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"otc-cli/errs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ecs/v1/cloudservertags"
+)
+
+// ServerSelector identifies the servers a command operates on.
+//
+// Every pattern is matched against server IDs and names; patterns containing
+// glob characters (*, ? or [) match names with path.Match semantics. Tags
+// narrow the matches of every pattern, or select among all servers without
+// patterns, to servers carrying all the given key=value tags. Unless All is
+// set, every pattern, narrowed by the tags, must resolve to exactly one
+// server.
+type ServerSelector struct {
+	Patterns []string
+	Tags     map[string]string
+	All      bool
+}
+
+// NotFoundError is returned when a selector does not match any server.
+type NotFoundError struct {
+	Query string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("no server matches '%s'", e.Query)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == errs.ErrNotFound
+}
+
+// AmbiguousError is returned when a selector matches several servers but
+// only one was expected.
+type AmbiguousError struct {
+	Query      string
+	Candidates []servers.Server
+}
+
+func (e *AmbiguousError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "'%s' matches %d servers, pass --all to select all of them or pick one of:", e.Query, len(e.Candidates))
+	for _, s := range e.Candidates {
+		fmt.Fprintf(&b, "\n  %s (%s)", s.Name, s.ID)
+	}
+	return b.String()
+}
+
+func (e *AmbiguousError) Is(target error) bool {
+	return target == errs.ErrAmbiguous
+}
+
+func (s ServerSelector) String() string {
+	parts := append([]string{}, s.Patterns...)
+
+	keys := make([]string, 0, len(s.Tags))
+	for k := range s.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		parts = append(parts, k+"="+s.Tags[k])
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func resolveServers(clients *serviceClients, selector ServerSelector) ([]servers.Server, error) {
+	if len(selector.Patterns) == 0 && len(selector.Tags) == 0 {
+		return nil, errors.New("no server specified, pass a name, ID, pattern or tag selector")
+	}
+
+	allServers, err := listServers(clients.compute, servers.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	return selectServers(allServers, selector, func(serverList []servers.Server) ([]map[string]string, error) {
+		return getServersTags(clients, serverList)
+	})
+}
+
+// tagsFunc returns the tags of the servers, in the order of the servers.
+type tagsFunc func(serverList []servers.Server) ([]map[string]string, error)
+
+// selectServers applies the selector to the servers. Tags are only fetched
+// for the servers the tag selector has to narrow down.
+func selectServers(allServers []servers.Server, selector ServerSelector, serversTags tagsFunc) ([]servers.Server, error) {
+	if len(selector.Patterns) == 0 {
+		candidates, err := filterByTags(allServers, selector.Tags, serversTags)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) == 0 {
+			return nil, &NotFoundError{Query: selector.String()}
+		}
+		if len(candidates) > 1 && !selector.All {
+			return nil, &AmbiguousError{Query: selector.String(), Candidates: candidates}
+		}
+		return candidates, nil
+	}
+
+	var candidates []servers.Server
+	seen := map[string]bool{}
+	for _, pattern := range selector.Patterns {
+		matches, err := matchPattern(allServers, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		// Tags narrow every pattern before it has to be unambiguous.
+		query := pattern
+		if len(selector.Tags) > 0 && len(matches) > 0 {
+			matches, err = filterByTags(matches, selector.Tags, serversTags)
+			if err != nil {
+				return nil, err
+			}
+			query = ServerSelector{Patterns: []string{pattern}, Tags: selector.Tags}.String()
+		}
+
+		if len(matches) == 0 {
+			return nil, &NotFoundError{Query: query}
+		}
+		if len(matches) > 1 && !selector.All {
+			return nil, &AmbiguousError{Query: query, Candidates: matches}
+		}
+
+		for _, server := range matches {
+			if !seen[server.ID] {
+				seen[server.ID] = true
+				candidates = append(candidates, server)
+			}
+		}
+	}
+
+	return candidates, nil
+}
+
+// resolveServer resolves a selector that must match exactly one server.
+func resolveServer(clients *serviceClients, selector ServerSelector) (*servers.Server, error) {
+	selector.All = false
+
+	serverList, err := resolveServers(clients, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(serverList) != 1 {
+		return nil, &AmbiguousError{Query: selector.String(), Candidates: serverList}
+	}
+
+	return &serverList[0], nil
+}
+
+func matchPattern(serverList []servers.Server, pattern string) ([]servers.Server, error) {
+	isGlob := strings.ContainsAny(pattern, "*?[")
+
+	var matches []servers.Server
+	for _, server := range serverList {
+		if server.ID == pattern {
+			return []servers.Server{server}, nil
+		}
+
+		if isGlob {
+			ok, err := path.Match(pattern, server.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+			}
+			if ok {
+				matches = append(matches, server)
+			}
+		} else if server.Name == pattern {
+			matches = append(matches, server)
+		}
+	}
+
+	return matches, nil
+}
+
+func filterByTags(serverList []servers.Server, tags map[string]string, serversTags tagsFunc) ([]servers.Server, error) {
+	serverTags, err := serversTags(serverList)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []servers.Server
+	for i, server := range serverList {
+		if hasTags(serverTags[i], tags) {
+			result = append(result, server)
+		}
+	}
+	return result, nil
+}
+
+// tagParallelism bounds the concurrent requests for server tags, which can
+// only be fetched one server at a time.
+const tagParallelism = 10
+
+// getServersTags fetches the tags of the servers concurrently and returns
+// them in the order of the servers.
+func getServersTags(clients *serviceClients, serverList []servers.Server) ([]map[string]string, error) {
+	tags := make([]map[string]string, len(serverList))
+	failures := make([]error, len(serverList))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(tagParallelism, len(serverList)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				tags[i], failures[i] = getServerTags(clients, serverList[i].ID)
+			}
+		}()
+	}
+
+	for i := range serverList {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range failures {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tags, nil
+}
+
+func getServerTags(clients *serviceClients, serverID string) (map[string]string, error) {
+	response, err := cloudservertags.Get(clients.ecs, serverID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags of server %s: %w", serverID, err)
+	}
+
+	tags := make(map[string]string, len(response.Tags))
+	for _, tag := range response.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+func hasTags(serverTags, wanted map[string]string) bool {
+	for k, v := range wanted {
+		if actual, ok := serverTags[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package ecs
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"otc-cli/errs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+)
+
+var testServers = []servers.Server{
+	{ID: "1f0c6d0e-0001", Name: "web-1"},
+	{ID: "1f0c6d0e-0002", Name: "web-2"},
+	{ID: "1f0c6d0e-0003", Name: "db"},
+	// a server named like the ID of another one
+	{ID: "1f0c6d0e-0004", Name: "1f0c6d0e-0003"},
+}
+
+var testTags = map[string]map[string]string{
+	"1f0c6d0e-0001": {"env": "prod"},
+	"1f0c6d0e-0002": {"env": "test"},
+	"1f0c6d0e-0003": {"env": "prod", "role": "db"},
+}
+
+func testServersTags(serverList []servers.Server) ([]map[string]string, error) {
+	tags := make([]map[string]string, len(serverList))
+	for i, server := range serverList {
+		tags[i] = testTags[server.ID]
+	}
+	return tags, nil
+}
+
+func serverNames(serverList []servers.Server) []string {
+	var names []string
+	for _, server := range serverList {
+		names = append(names, server.Name)
+	}
+	return names
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{pattern: "web-1", want: []string{"web-1"}},
+		{pattern: "web", want: nil},
+		{pattern: "web-*", want: []string{"web-1", "web-2"}},
+		{pattern: "web-?", want: []string{"web-1", "web-2"}},
+		{pattern: "[dw]*", want: []string{"web-1", "web-2", "db"}},
+		{pattern: "1f0c6d0e-0002", want: []string{"web-2"}},
+		// an ID wins over a server with that name
+		{pattern: "1f0c6d0e-0003", want: []string{"db"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			matches, err := matchPattern(testServers, tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := serverNames(matches); !slices.Equal(got, tt.want) {
+				t.Errorf("matchPattern(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchPatternInvalid(t *testing.T) {
+	if _, err := matchPattern(testServers, "web-[1"); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestSelectServers(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector ServerSelector
+		want     []string
+		wantErr  error
+	}{
+		{
+			name:     "name",
+			selector: ServerSelector{Patterns: []string{"db"}},
+			want:     []string{"db"},
+		},
+		{
+			name:     "several patterns without duplicates",
+			selector: ServerSelector{Patterns: []string{"web-1", "1f0c6d0e-0001", "db"}},
+			want:     []string{"web-1", "db"},
+		},
+		{
+			name:     "not found",
+			selector: ServerSelector{Patterns: []string{"db", "cache"}},
+			wantErr:  errs.ErrNotFound,
+		},
+		{
+			name:     "ambiguous glob",
+			selector: ServerSelector{Patterns: []string{"web-*"}},
+			wantErr:  errs.ErrAmbiguous,
+		},
+		{
+			name:     "glob with all",
+			selector: ServerSelector{Patterns: []string{"web-*"}, All: true},
+			want:     []string{"web-1", "web-2"},
+		},
+		{
+			name:     "tags narrow a pattern",
+			selector: ServerSelector{Patterns: []string{"web-*"}, Tags: map[string]string{"env": "prod"}},
+			want:     []string{"web-1"},
+		},
+		{
+			name:     "tags narrow every pattern",
+			selector: ServerSelector{Patterns: []string{"web-*", "db"}, Tags: map[string]string{"env": "prod"}},
+			want:     []string{"web-1", "db"},
+		},
+		{
+			name:     "tags exclude a pattern",
+			selector: ServerSelector{Patterns: []string{"web-2"}, Tags: map[string]string{"env": "prod"}},
+			wantErr:  errs.ErrNotFound,
+		},
+		{
+			name:     "tags only",
+			selector: ServerSelector{Tags: map[string]string{"role": "db"}},
+			want:     []string{"db"},
+		},
+		{
+			name:     "ambiguous tags",
+			selector: ServerSelector{Tags: map[string]string{"env": "prod"}},
+			wantErr:  errs.ErrAmbiguous,
+		},
+		{
+			name:     "tags with all",
+			selector: ServerSelector{Tags: map[string]string{"env": "prod"}, All: true},
+			want:     []string{"web-1", "db"},
+		},
+		{
+			name:     "tags match nothing",
+			selector: ServerSelector{Tags: map[string]string{"env": "staging"}, All: true},
+			wantErr:  errs.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectServers(testServers, tt.selector, testServersTags)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("selectServers() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := serverNames(selected); !slices.Equal(got, tt.want) {
+				t.Errorf("selectServers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectServersAmbiguousCandidates(t *testing.T) {
+	_, err := selectServers(testServers, ServerSelector{Patterns: []string{"web-*"}}, testServersTags)
+
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected an AmbiguousError, got %v", err)
+	}
+	if ambiguous.Query != "web-*" {
+		t.Errorf("Query = %q, want %q", ambiguous.Query, "web-*")
+	}
+	if got := serverNames(ambiguous.Candidates); !slices.Equal(got, []string{"web-1", "web-2"}) {
+		t.Errorf("Candidates = %v", got)
+	}
+}
+
+func TestSelectServersSkipsTagsWithoutMatches(t *testing.T) {
+	selector := ServerSelector{Patterns: []string{"cache"}, Tags: map[string]string{"env": "prod"}}
+	_, err := selectServers(testServers, selector, func([]servers.Server) ([]map[string]string, error) {
+		t.Error("tags fetched for a pattern without matches")
+		return nil, nil
+	})
+	if !errors.Is(err, errs.ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
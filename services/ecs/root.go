@@ -7,11 +7,10 @@ import (
 
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
-	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/startstop"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
 )
 
-func getComputeClouds(commonConfig *config.CommonConfig) (*golangsdk.ServiceClient, error) {
+func getProviderClient(commonConfig *config.CommonConfig) (*golangsdk.ProviderClient, error) {
 	opts, err := client.GetAuthOpts(commonConfig)
 	if err != nil {
 		return nil, err
@@ -19,113 +18,133 @@ func getComputeClouds(commonConfig *config.CommonConfig) (*golangsdk.ServiceClie
 
 	client, err := client.GetAuthenticatedClient(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate client: %s", err)
+		return nil, fmt.Errorf("failed to authenticate client: %w", err)
 	}
 
-	return openstack.NewComputeV2(client, golangsdk.EndpointOpts{
-		Region: commonConfig.Region,
-	})
+	return client, nil
 }
 
-type ListArgs struct {
-	Limit        int
-	Filter       string
-	CommonConfig *config.CommonConfig
+// serviceClients bundles the Nova compute client with the OTC specific ECS
+// client, both sharing one authenticated provider. Clients for other services
+// can be created from provider and endpointOpts when needed.
+type serviceClients struct {
+	compute *golangsdk.ServiceClient
+	ecs     *golangsdk.ServiceClient
+
+	provider     *golangsdk.ProviderClient
+	endpointOpts golangsdk.EndpointOpts
 }
 
-func List(args ListArgs) ([]servers.Server, error) {
-	compute, err := getComputeClouds(args.CommonConfig)
+func getServiceClients(commonConfig *config.CommonConfig) (*serviceClients, error) {
+	provider, err := getProviderClient(commonConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Compute client: %w", err)
-	}
-
-	opts := servers.ListOpts{}
-	if args.Limit > 0 {
-		opts.Limit = args.Limit
-	}
-	if args.Filter != "" {
-		opts.Name = args.Filter
+		return nil, err
 	}
 
-	serverPage := servers.List(compute, opts)
-	if serverPage.Err != nil {
-		return nil, fmt.Errorf("failed to list servers: %w", serverPage.Err)
+	endpointOpts := golangsdk.EndpointOpts{
+		Region: commonConfig.Region,
 	}
 
-	allPages, err := serverPage.AllPages()
+	compute, err := openstack.NewComputeV2(provider, endpointOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get all pages of servers: %w", err)
+		return nil, fmt.Errorf("failed to create Compute client: %w", err)
 	}
 
-	serverList, err := servers.ExtractServers(allPages)
+	ecs, err := openstack.NewComputeV1(provider, endpointOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract servers: %w", err)
+		return nil, fmt.Errorf("failed to create ECS client: %w", err)
 	}
 
-	return serverList, nil
+	return &serviceClients{
+		compute:      compute,
+		ecs:          ecs,
+		provider:     provider,
+		endpointOpts: endpointOpts,
+	}, nil
 }
 
-func getServerByName(compute *golangsdk.ServiceClient, name string) (*servers.Server, error) {
-	opts := servers.ListOpts{
-		Name:  name,
-		Limit: 2,
-	}
+type ListArgs struct {
+	Limit  int
+	Filter string
+	// Tags limits the result to servers carrying all the given tags.
+	Tags map[string]string
+	// WithTags loads the tags of every listed server.
+	WithTags     bool
+	CommonConfig *config.CommonConfig
+}
 
-	serverPage := servers.List(compute, opts)
-	if serverPage.Err != nil {
-		return nil, fmt.Errorf("failed to list servers: %w", serverPage.Err)
-	}
+// Server is a compute server together with its resolved flavor and image
+// and its ECS tags. Tags are only populated when requested, since they take
+// one API call per server, made concurrently.
+type Server struct {
+	servers.Server `yaml:",inline"`
 
-	allPages, err := serverPage.AllPages()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get all pages of servers: %w", err)
-	}
+	FlavorDetails *Flavor           `json:"flavor_details,omitempty"`
+	ImageName     string            `json:"image_name,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
 
-	serverList, err := servers.ExtractServers(allPages)
+// List returns the servers with their flavor and image names. Failing to
+// look up the names only leaves their IDs and is reported in the warnings.
+func List(args ListArgs) ([]Server, []error, error) {
+	clients, err := getServiceClients(args.CommonConfig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract servers: %w", err)
+		return nil, nil, err
 	}
 
-	if len(serverList) != 1 {
-		return nil, fmt.Errorf("expected exactly one server with name %s, but found %d", name, len(serverList))
+	opts := servers.ListOpts{}
+	if args.Limit > 0 {
+		opts.Limit = args.Limit
+	}
+	if args.Filter != "" {
+		opts.Name = args.Filter
 	}
 
-	return &serverList[0], nil
-}
-
-func StartServer(name string, commonConfig *config.CommonConfig) error {
-	compute, err := getComputeClouds(commonConfig)
+	serverList, err := listServers(clients.compute, opts)
 	if err != nil {
-		return fmt.Errorf("failed to create Compute client: %w", err)
+		return nil, nil, err
 	}
 
-	server, err := getServerByName(compute, name)
-	if err != nil {
-		return err
+	var serverTags []map[string]string
+	if args.WithTags || len(args.Tags) > 0 {
+		serverTags, err = getServersTags(clients, serverList)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	err = startstop.Start(compute, server.ID).ExtractErr()
-	if err != nil {
-		return fmt.Errorf("failed to start server %s: %w", name, err)
+	lookups := newLookupCache(clients)
+	result := make([]Server, 0, len(serverList))
+	for i, server := range serverList {
+		item := Server{Server: server}
+		if serverTags != nil {
+			item.Tags = serverTags[i]
+			if !hasTags(item.Tags, args.Tags) {
+				continue
+			}
+		}
+		lookups.resolveNames(&item)
+		result = append(result, item)
 	}
 
-	return nil
+	return result, lookups.warnings, nil
 }
 
-func StopServer(name string, commonConfig *config.CommonConfig) error {
-	compute, err := getComputeClouds(commonConfig)
-	if err != nil {
-		return fmt.Errorf("failed to create Compute client: %w", err)
+func listServers(compute *golangsdk.ServiceClient, opts servers.ListOpts) ([]servers.Server, error) {
+	serverPage := servers.List(compute, opts)
+	if serverPage.Err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", serverPage.Err)
 	}
 
-	server, err := getServerByName(compute, name)
+	allPages, err := serverPage.AllPages()
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to get all pages of servers: %w", err)
 	}
 
-	err = startstop.Stop(compute, server.ID).ExtractErr()
+	serverList, err := servers.ExtractServers(allPages)
 	if err != nil {
-		return fmt.Errorf("failed to stop server %s: %w", name, err)
+		return nil, fmt.Errorf("failed to extract servers: %w", err)
 	}
-	return nil
+
+	return serverList, nil
 }
@@ -0,0 +1,135 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ecs/v1/cloudservers"
+)
+
+// Address types reported by the compute API.
+const (
+	AddressFloating = "floating"
+	AddressFixed    = "fixed"
+)
+
+// Address is a single IP address assigned to a server.
+type Address struct {
+	Network string `json:"network"`
+	Addr    string `json:"addr"`
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+}
+
+// ServerDetails extends the compute server with attributes only exposed by the ECS API.
+type ServerDetails struct {
+	servers.Server `yaml:",inline"`
+
+	AvailabilityZone string              `json:"availability_zone"`
+	FlavorDetails    cloudservers.Flavor `json:"flavor_details"`
+	ImageName        string              `json:"image_name"`
+	Tags             map[string]string   `json:"tags"`
+}
+
+// Addresses flattens the untyped address map of a server, ordered by network name.
+func Addresses(s servers.Server) []Address {
+	networks := make([]string, 0, len(s.Addresses))
+	for network := range s.Addresses {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+
+	var result []Address
+	for _, network := range networks {
+		entries, ok := s.Addresses[network].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, entry := range entries {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			address := Address{Network: network}
+			address.Addr, _ = fields["addr"].(string)
+			address.Type, _ = fields["OS-EXT-IPS:type"].(string)
+			if version, ok := fields["version"].(float64); ok {
+				address.Version = int(version)
+			}
+			result = append(result, address)
+		}
+	}
+
+	return result
+}
+
+// PreferredAddress returns the first IPv4 address of the preferred type
+// (AddressFloating or AddressFixed), falling back to any IPv4 address.
+func PreferredAddress(s servers.Server, preferred string) (string, error) {
+	if preferred != AddressFloating && preferred != AddressFixed {
+		return "", fmt.Errorf("unknown address type '%s', expected %s or %s", preferred, AddressFloating, AddressFixed)
+	}
+
+	var fallback string
+	for _, address := range Addresses(s) {
+		if address.Version != 4 {
+			continue
+		}
+		if address.Type == preferred {
+			return address.Addr, nil
+		}
+		if fallback == "" {
+			fallback = address.Addr
+		}
+	}
+
+	if fallback == "" {
+		return "", fmt.Errorf("server %s has no IPv4 address", s.Name)
+	}
+	return fallback, nil
+}
+
+// Find resolves a selector to exactly one server.
+func Find(selector ServerSelector, commonConfig *config.CommonConfig) (*servers.Server, error) {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveServer(clients, selector)
+}
+
+func Show(selector ServerSelector, commonConfig *config.CommonConfig) (*ServerDetails, error) {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	cloudServer, err := cloudservers.Get(clients.ecs, server.ID).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get details of server %s: %w", server.Name, err)
+	}
+
+	tags, err := getServerTags(clients, server.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerDetails{
+		Server:           *server,
+		AvailabilityZone: cloudServer.AvailabilityZone,
+		FlavorDetails:    cloudServer.Flavor,
+		ImageName:        cloudServer.Metadata.ImageName,
+		Tags:             tags,
+	}, nil
+}
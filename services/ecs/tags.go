@@ -0,0 +1,103 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ecs/v1/cloudservertags"
+)
+
+// KeyValue is a single tag or metadata entry.
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func ListTags(selector ServerSelector, commonConfig *config.CommonConfig) ([]KeyValue, error) {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := getServerTags(clients, server.ID)
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeyValues(tags), nil
+}
+
+// AddTags adds tags to a server, replacing the values of existing keys.
+func AddTags(selector ServerSelector, tags map[string]string, commonConfig *config.CommonConfig) error {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return err
+	}
+
+	return batchTags(clients, server, cloudservertags.ActionCreate, tags)
+}
+
+// RemoveTags removes the tags with the given keys from a server.
+func RemoveTags(selector ServerSelector, keys []string, commonConfig *config.CommonConfig) error {
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return err
+	}
+
+	server, err := resolveServer(clients, selector)
+	if err != nil {
+		return err
+	}
+
+	current, err := getServerTags(clients, server.ID)
+	if err != nil {
+		return err
+	}
+
+	// the API expects the current values of the tags being deleted
+	tags := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, ok := current[key]
+		if !ok {
+			return fmt.Errorf("server %s has no tag '%s'", server.Name, key)
+		}
+		tags[key] = value
+	}
+
+	return batchTags(clients, server, cloudservertags.ActionDelete, tags)
+}
+
+func batchTags(clients *serviceClients, server *servers.Server, action cloudservertags.ActionType, tags map[string]string) error {
+	opts := cloudservertags.BatchOpts{Action: action}
+	for _, kv := range sortedKeyValues(tags) {
+		opts.Tags = append(opts.Tags, cloudservertags.Tag{Key: kv.Key, Value: kv.Value})
+	}
+
+	if err := cloudservertags.BatchAction(clients.ecs, server.ID, opts).Err; err != nil {
+		return fmt.Errorf("failed to %s tags of server %s: %w", action, server.Name, err)
+	}
+	return nil
+}
+
+func sortedKeyValues(m map[string]string) []KeyValue {
+	result := make([]KeyValue, 0, len(m))
+	for k, v := range m {
+		result = append(result, KeyValue{Key: k, Value: v})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
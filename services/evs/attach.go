@@ -0,0 +1,150 @@
+package evs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"otc-cli/config"
+	"otc-cli/services/ecs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/volumes"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/extensions/volumeattach"
+)
+
+type AttachArgs struct {
+	Volume string
+	Server ecs.ServerSelector
+	// Device is the requested device name, e.g. /dev/vdb; empty lets the
+	// cloud choose.
+	Device       string
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+// Attach attaches a volume to a server and returns the assigned device.
+func Attach(args AttachArgs) (string, error) {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return "", err
+	}
+
+	volume, err := findVolume(clients.blockStorage, args.Volume)
+	if err != nil {
+		return "", err
+	}
+
+	server, err := ecs.Find(args.Server, args.CommonConfig)
+	if err != nil {
+		return "", err
+	}
+
+	attachment, err := volumeattach.Create(clients.compute, server.ID, volumeattach.CreateOpts{
+		VolumeID: volume.ID,
+		Device:   args.Device,
+	}).Extract()
+	if err != nil {
+		return "", fmt.Errorf("failed to attach volume %s to server %s: %w", volume.Name, server.Name, err)
+	}
+
+	if args.Wait {
+		if err := waitForStatus(clients.blockStorage, volume.ID, StatusInUse, int(args.Timeout.Seconds())); err != nil {
+			return "", err
+		}
+	}
+	return attachment.Device, nil
+}
+
+type DetachArgs struct {
+	Volume string
+	// Server is only required for volumes attached to several servers.
+	Server       ecs.ServerSelector
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+func Detach(args DetachArgs) error {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return err
+	}
+
+	volume, err := findVolume(clients.blockStorage, args.Volume)
+	if err != nil {
+		return err
+	}
+
+	var serverID string
+	switch {
+	case len(args.Server.Patterns) > 0 || len(args.Server.Tags) > 0:
+		server, err := ecs.Find(args.Server, args.CommonConfig)
+		if err != nil {
+			return err
+		}
+		serverID = server.ID
+	case len(volume.Attachments) == 0:
+		return fmt.Errorf("volume %s is not attached", args.Volume)
+	case len(volume.Attachments) > 1:
+		return fmt.Errorf("volume %s is attached to %d servers, specify the server to detach from", args.Volume, len(volume.Attachments))
+	default:
+		serverID = volume.Attachments[0].ServerID
+	}
+
+	if err := volumeattach.Delete(clients.compute, serverID, volume.ID).ExtractErr(); err != nil {
+		return fmt.Errorf("failed to detach volume %s: %w", volume.Name, err)
+	}
+
+	if !args.Wait {
+		return nil
+	}
+
+	expected := StatusAvailable
+	if len(volume.Attachments) > 1 {
+		expected = StatusInUse
+	}
+	return waitForVolume(clients.blockStorage, volume.ID, "detached", int(args.Timeout.Seconds()), func(v *volumes.Volume) bool {
+		return v.Status == expected && len(v.Attachments) == len(volume.Attachments)-1
+	})
+}
+
+// ServerVolume is a volume together with the device it is attached as.
+type ServerVolume struct {
+	Device         string `json:"device"`
+	volumes.Volume `yaml:",inline"`
+}
+
+// ServerVolumes lists the volumes attached to a server, ordered by device.
+func ServerVolumes(selector ecs.ServerSelector, commonConfig *config.CommonConfig) ([]ServerVolume, error) {
+	server, err := ecs.Find(selector, commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := getServiceClients(commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ServerVolume
+	for _, attached := range server.VolumesAttached {
+		volume, err := volumes.Get(clients.blockStorage, attached.ID).Extract()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get volume %s: %w", attached.ID, err)
+		}
+
+		item := ServerVolume{Volume: *volume}
+		for _, attachment := range volume.Attachments {
+			if attachment.ServerID == server.ID {
+				item.Device = attachment.Device
+			}
+		}
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Device < result[j].Device
+	})
+	return result, nil
+}
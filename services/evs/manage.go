@@ -0,0 +1,145 @@
+package evs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/volumes"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/evs/extensions/volumeactions"
+)
+
+// ErrDeleteAborted is returned when the deletion was not confirmed.
+var ErrDeleteAborted = errors.New("deletion aborted")
+
+type CreateArgs struct {
+	Name             string
+	Size             int
+	VolumeType       string
+	AvailabilityZone string
+	Description      string
+	// SnapshotID creates the volume from a snapshot.
+	SnapshotID   string
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+func Create(args CreateArgs) (*volumes.Volume, error) {
+	if args.Size <= 0 && args.SnapshotID == "" {
+		return nil, errors.New("volume size is required")
+	}
+
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := volumes.Create(clients.blockStorage, volumes.CreateOpts{
+		Name:             args.Name,
+		Size:             args.Size,
+		VolumeType:       args.VolumeType,
+		AvailabilityZone: args.AvailabilityZone,
+		Description:      args.Description,
+		SnapshotID:       args.SnapshotID,
+	}).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create volume: %w", err)
+	}
+
+	if !args.Wait {
+		return volume, nil
+	}
+
+	if err := waitForStatus(clients.blockStorage, volume.ID, StatusAvailable, int(args.Timeout.Seconds())); err != nil {
+		return nil, err
+	}
+	return volumes.Get(clients.blockStorage, volume.ID).Extract()
+}
+
+type DeleteArgs struct {
+	Volumes []string
+	// Confirm is called with the volumes about to be deleted. Deletion only
+	// proceeds when it returns true.
+	Confirm      func(volumeList []volumes.Volume) (bool, error)
+	CommonConfig *config.CommonConfig
+}
+
+// Delete deletes volumes, refusing volumes that are still attached.
+func Delete(args DeleteArgs) error {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return err
+	}
+
+	var volumeList []volumes.Volume
+	for _, nameOrID := range args.Volumes {
+		volume, err := findVolume(clients.blockStorage, nameOrID)
+		if err != nil {
+			return err
+		}
+		if len(volume.Attachments) > 0 {
+			return fmt.Errorf("volume %s is attached to server %s, detach it first", nameOrID, volume.Attachments[0].ServerID)
+		}
+		volumeList = append(volumeList, *volume)
+	}
+
+	if args.Confirm != nil {
+		confirmed, err := args.Confirm(volumeList)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return ErrDeleteAborted
+		}
+	}
+
+	for _, volume := range volumeList {
+		if err := volumes.Delete(clients.blockStorage, volume.ID).ExtractErr(); err != nil {
+			return fmt.Errorf("failed to delete volume %s: %w", volume.Name, err)
+		}
+	}
+	return nil
+}
+
+type ExtendArgs struct {
+	Volume string
+	// Size is the new size in GB.
+	Size         int
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+// Extend grows a volume. Attached volumes are extended online; the file
+// system still has to be grown inside the server.
+func Extend(args ExtendArgs) error {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return err
+	}
+
+	volume, err := findVolume(clients.blockStorage, args.Volume)
+	if err != nil {
+		return err
+	}
+	if args.Size <= volume.Size {
+		return fmt.Errorf("new size %d GB must be larger than the current size of %d GB", args.Size, volume.Size)
+	}
+
+	err = volumeactions.ExtendSize(clients.blockStorage, volume.ID, volumeactions.ExtendSizeOpts{NewSize: args.Size})
+	if err != nil {
+		return fmt.Errorf("failed to extend volume %s: %w", volume.Name, err)
+	}
+
+	if !args.Wait {
+		return nil
+	}
+	status := volume.Status
+	description := fmt.Sprintf("%s with %d GB", status, args.Size)
+	return waitForVolume(clients.blockStorage, volume.ID, description, int(args.Timeout.Seconds()), func(v *volumes.Volume) bool {
+		return v.Size == args.Size && v.Status == status
+	})
+}
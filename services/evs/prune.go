@@ -0,0 +1,98 @@
+package evs
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"otc-cli/config"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/snapshots"
+)
+
+type PruneArgs struct {
+	// Volume limits pruning to snapshots of the volume (name or ID).
+	Volume string
+	// Selector limits pruning to snapshots carrying all the metadata.
+	Selector map[string]string
+	// OlderThan is the minimum age of snapshots to delete.
+	OlderThan time.Duration
+	// KeepLast is the number of newest snapshots kept per volume regardless
+	// of their age.
+	KeepLast int
+	// Confirm is called with the planned deletions. Deletion only proceeds
+	// when it returns true.
+	Confirm      func(plan []snapshots.Snapshot) (bool, error)
+	CommonConfig *config.CommonConfig
+}
+
+// PruneSnapshots deletes old snapshots, keeping the newest ones of every
+// volume. It returns the deleted snapshots.
+func PruneSnapshots(args PruneArgs) ([]snapshots.Snapshot, error) {
+	if args.OlderThan <= 0 && args.KeepLast <= 0 {
+		return nil, errors.New("refusing to prune without an age or a number of snapshots to keep")
+	}
+
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotList, err := listSnapshots(clients, args.Volume, args.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := planPrune(snapshotList, args.OlderThan, args.KeepLast, time.Now())
+	if len(plan) == 0 {
+		return nil, nil
+	}
+
+	if err := deleteSnapshots(clients, plan, args.Confirm); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// planPrune selects the available snapshots older than olderThan, except for
+// the keepLast newest available snapshots of each volume. Snapshots in any
+// other state are neither deleted nor counted as kept, so that failed
+// snapshots cannot push the last good ones out.
+func planPrune(snapshotList []snapshots.Snapshot, olderThan time.Duration, keepLast int, now time.Time) []snapshots.Snapshot {
+	byVolume := map[string][]snapshots.Snapshot{}
+	for _, snapshot := range snapshotList {
+		byVolume[snapshot.VolumeID] = append(byVolume[snapshot.VolumeID], snapshot)
+	}
+
+	cutoff := now.Add(-olderThan)
+
+	var plan []snapshots.Snapshot
+	for _, volumeSnapshots := range byVolume {
+		sort.Slice(volumeSnapshots, func(i, j int) bool {
+			return volumeSnapshots[i].CreatedAt.After(volumeSnapshots[j].CreatedAt)
+		})
+
+		kept := 0
+		for _, snapshot := range volumeSnapshots {
+			if snapshot.Status != StatusAvailable {
+				continue
+			}
+			if kept < keepLast {
+				kept++
+				continue
+			}
+			if snapshot.CreatedAt.After(cutoff) {
+				continue
+			}
+			plan = append(plan, snapshot)
+		}
+	}
+
+	sort.Slice(plan, func(i, j int) bool {
+		if plan[i].VolumeID != plan[j].VolumeID {
+			return plan[i].VolumeID < plan[j].VolumeID
+		}
+		return plan[i].CreatedAt.Before(plan[j].CreatedAt)
+	})
+	return plan
+}
@@ -0,0 +1,105 @@
+package evs
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/snapshots"
+)
+
+func TestPlanPrune(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	snapshot := func(id, volumeID, status string, age time.Duration) snapshots.Snapshot {
+		return snapshots.Snapshot{ID: id, VolumeID: volumeID, Status: status, CreatedAt: now.Add(-age)}
+	}
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		snapshots []snapshots.Snapshot
+		olderThan time.Duration
+		keepLast  int
+		want      []string
+	}{
+		{
+			name: "age only",
+			snapshots: []snapshots.Snapshot{
+				snapshot("new", "vol-a", StatusAvailable, 1*day),
+				snapshot("old", "vol-a", StatusAvailable, 20*day),
+			},
+			olderThan: 14 * day,
+			want:      []string{"old"},
+		},
+		{
+			name: "cutoff boundary",
+			snapshots: []snapshots.Snapshot{
+				snapshot("younger", "vol-a", StatusAvailable, 14*day-time.Second),
+				snapshot("exact", "vol-a", StatusAvailable, 14*day),
+				snapshot("older", "vol-a", StatusAvailable, 14*day+time.Second),
+			},
+			olderThan: 14 * day,
+			want:      []string{"older", "exact"},
+		},
+		{
+			name: "keep last only",
+			snapshots: []snapshots.Snapshot{
+				snapshot("s1", "vol-a", StatusAvailable, 1*day),
+				snapshot("s2", "vol-a", StatusAvailable, 2*day),
+				snapshot("s3", "vol-a", StatusAvailable, 3*day),
+			},
+			keepLast: 2,
+			want:     []string{"s3"},
+		},
+		{
+			name: "keep last counts per volume",
+			snapshots: []snapshots.Snapshot{
+				snapshot("a1", "vol-a", StatusAvailable, 20*day),
+				snapshot("a2", "vol-a", StatusAvailable, 30*day),
+				snapshot("b1", "vol-b", StatusAvailable, 15*day),
+				snapshot("b2", "vol-b", StatusAvailable, 25*day),
+				snapshot("b3", "vol-b", StatusAvailable, 35*day),
+			},
+			olderThan: 14 * day,
+			keepLast:  1,
+			want:      []string{"a2", "b3", "b2"},
+		},
+		{
+			name: "other states are neither deleted nor kept",
+			snapshots: []snapshots.Snapshot{
+				snapshot("error1", "vol-a", StatusError, 1*day),
+				snapshot("creating", "vol-a", "creating", 2*day),
+				snapshot("error2", "vol-a", StatusError, 3*day),
+				snapshot("good1", "vol-a", StatusAvailable, 20*day),
+				snapshot("good2", "vol-a", StatusAvailable, 21*day),
+				snapshot("good3", "vol-a", StatusAvailable, 22*day),
+				snapshot("error3", "vol-a", StatusError, 40*day),
+			},
+			olderThan: 14 * day,
+			keepLast:  2,
+			want:      []string{"good3"},
+		},
+		{
+			name: "fewer available snapshots than kept",
+			snapshots: []snapshots.Snapshot{
+				snapshot("error", "vol-a", StatusError, 1*day),
+				snapshot("good", "vol-a", StatusAvailable, 20*day),
+			},
+			olderThan: 14 * day,
+			keepLast:  2,
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, s := range planPrune(tt.snapshots, tt.olderThan, tt.keepLast, now) {
+				got = append(got, s.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("planPrune() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package evs
+
+import (
+	"fmt"
+	"otc-cli/client"
+	"otc-cli/config"
+	"otc-cli/errs"
+	"sort"
+	"strings"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/volumes"
+)
+
+// Volume statuses reported by the block storage API.
+const (
+	StatusAvailable = "available"
+	StatusInUse     = "in-use"
+	StatusError     = "error"
+)
+
+// serviceClients bundles the block storage client with the compute client
+// used for attaching volumes, both sharing one authenticated provider.
+// Clients for other API versions can be created from provider and
+// endpointOpts when needed.
+type serviceClients struct {
+	blockStorage *golangsdk.ServiceClient
+	compute      *golangsdk.ServiceClient
+
+	provider     *golangsdk.ProviderClient
+	endpointOpts golangsdk.EndpointOpts
+}
+
+func getServiceClients(commonConfig *config.CommonConfig) (*serviceClients, error) {
+	opts, err := client.GetAuthOpts(commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := client.GetAuthenticatedClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate client: %w", err)
+	}
+
+	endpointOpts := golangsdk.EndpointOpts{
+		Region: commonConfig.Region,
+	}
+
+	blockStorage, err := openstack.NewBlockStorageV3(provider, endpointOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create EVS client: %w", err)
+	}
+
+	compute, err := openstack.NewComputeV2(provider, endpointOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Compute client: %w", err)
+	}
+
+	return &serviceClients{
+		blockStorage: blockStorage,
+		compute:      compute,
+		provider:     provider,
+		endpointOpts: endpointOpts,
+	}, nil
+}
+
+type ListArgs struct {
+	Filter       string
+	Status       string
+	CommonConfig *config.CommonConfig
+}
+
+func List(args ListArgs) ([]volumes.Volume, error) {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeList, err := listVolumes(clients.blockStorage, volumes.ListOpts{
+		Name:   args.Filter,
+		Status: args.Status,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(volumeList, func(i, j int) bool {
+		return volumeList[i].Name < volumeList[j].Name
+	})
+	return volumeList, nil
+}
+
+func listVolumes(blockStorage *golangsdk.ServiceClient, opts volumes.ListOpts) ([]volumes.Volume, error) {
+	allPages, err := volumes.List(blockStorage, opts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+
+	volumeList, err := volumes.ExtractVolumes(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract volumes: %w", err)
+	}
+
+	return volumeList, nil
+}
+
+// findVolume looks up a volume by ID or exact name, which has to be unique.
+func findVolume(blockStorage *golangsdk.ServiceClient, nameOrID string) (*volumes.Volume, error) {
+	volumeList, err := listVolumes(blockStorage, volumes.ListOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []volumes.Volume
+	for _, volume := range volumeList {
+		if volume.ID == nameOrID {
+			return &volume, nil
+		}
+		if volume.Name == nameOrID {
+			matches = append(matches, volume)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &errs.NotFoundError{Resource: "volume", Name: nameOrID}
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &errs.AmbiguousError{Resource: "volume", Name: nameOrID, Count: len(matches), Hint: "use the volume ID"}
+	}
+}
+
+// waitForStatus polls a volume until it reaches the status.
+func waitForStatus(blockStorage *golangsdk.ServiceClient, id, status string, secs int) error {
+	return waitForVolume(blockStorage, id, status, secs, func(volume *volumes.Volume) bool {
+		return volume.Status == status
+	})
+}
+
+// waitForVolume polls a volume until done returns true, failing early when
+// the volume turns into an error state. The description is used in errors.
+func waitForVolume(blockStorage *golangsdk.ServiceClient, id, description string, secs int, done func(volume *volumes.Volume) bool) error {
+	err := golangsdk.WaitFor(secs, func() (bool, error) {
+		volume, err := volumes.Get(blockStorage, id).Extract()
+		if err != nil {
+			return false, err
+		}
+		if strings.HasPrefix(volume.Status, StatusError) {
+			return false, fmt.Errorf("volume is in status %s", volume.Status)
+		}
+		return done(volume), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for volume %s to become %s: %w", id, description, err)
+	}
+	return nil
+}
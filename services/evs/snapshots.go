@@ -0,0 +1,271 @@
+package evs
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"otc-cli/config"
+	"otc-cli/errs"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/blockstorage/v3/snapshots"
+)
+
+type SnapshotListArgs struct {
+	// Volume limits the result to snapshots of the volume (name or ID).
+	Volume string
+	// Selector limits the result to snapshots carrying all the metadata.
+	Selector     map[string]string
+	CommonConfig *config.CommonConfig
+}
+
+// ListSnapshots returns the snapshots ordered by volume and creation time.
+func ListSnapshots(args SnapshotListArgs) ([]snapshots.Snapshot, error) {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return listSnapshots(clients, args.Volume, args.Selector)
+}
+
+func listSnapshots(clients *serviceClients, volume string, selector map[string]string) ([]snapshots.Snapshot, error) {
+	opts := snapshots.ListOpts{}
+	if volume != "" {
+		v, err := findVolume(clients.blockStorage, volume)
+		if err != nil {
+			return nil, err
+		}
+		opts.VolumeID = v.ID
+	}
+
+	allPages, err := snapshots.List(clients.blockStorage, opts).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	snapshotList, err := snapshots.ExtractSnapshots(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract snapshots: %w", err)
+	}
+
+	var result []snapshots.Snapshot
+	for _, snapshot := range snapshotList {
+		if hasMetadata(snapshot.Metadata, selector) {
+			result = append(result, snapshot)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].VolumeID != result[j].VolumeID {
+			return result[i].VolumeID < result[j].VolumeID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+type SnapshotCreateArgs struct {
+	Volume      string
+	Name        string
+	Description string
+	Metadata    map[string]string
+	// Force snapshots volumes that are attached to a server.
+	Force        bool
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+func CreateSnapshot(args SnapshotCreateArgs) (*snapshots.Snapshot, error) {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := findVolume(clients.blockStorage, args.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := snapshots.Create(clients.blockStorage, snapshots.CreateOpts{
+		VolumeID:    volume.ID,
+		Name:        args.Name,
+		Description: args.Description,
+		Metadata:    args.Metadata,
+		Force:       args.Force,
+	}).Extract()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot of volume %s: %w", volume.Name, err)
+	}
+
+	if !args.Wait {
+		return snapshot, nil
+	}
+
+	if err := snapshots.WaitForStatus(clients.blockStorage, snapshot.ID, StatusAvailable, int(args.Timeout.Seconds())); err != nil {
+		return nil, fmt.Errorf("failed waiting for snapshot %s: %w", snapshot.ID, err)
+	}
+	return snapshots.Get(clients.blockStorage, snapshot.ID).Extract()
+}
+
+type SnapshotDeleteArgs struct {
+	Snapshots []string
+	// Confirm is called with the snapshots about to be deleted. Deletion
+	// only proceeds when it returns true.
+	Confirm      func(snapshotList []snapshots.Snapshot) (bool, error)
+	CommonConfig *config.CommonConfig
+}
+
+func DeleteSnapshots(args SnapshotDeleteArgs) error {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return err
+	}
+
+	var snapshotList []snapshots.Snapshot
+	for _, nameOrID := range args.Snapshots {
+		snapshot, err := findSnapshot(clients, nameOrID)
+		if err != nil {
+			return err
+		}
+		snapshotList = append(snapshotList, *snapshot)
+	}
+
+	return deleteSnapshots(clients, snapshotList, args.Confirm)
+}
+
+func deleteSnapshots(clients *serviceClients, snapshotList []snapshots.Snapshot, confirm func([]snapshots.Snapshot) (bool, error)) error {
+	if confirm != nil {
+		confirmed, err := confirm(snapshotList)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return ErrDeleteAborted
+		}
+	}
+
+	for _, snapshot := range snapshotList {
+		if err := snapshots.Delete(clients.blockStorage, snapshot.ID).ExtractErr(); err != nil {
+			return fmt.Errorf("failed to delete snapshot %s: %w", snapshot.Name, err)
+		}
+	}
+	return nil
+}
+
+type SnapshotRestoreArgs struct {
+	Snapshot string
+	// NewVolume creates a volume with this name from the snapshot instead of
+	// rolling back the source volume.
+	NewVolume    string
+	Wait         bool
+	Timeout      time.Duration
+	CommonConfig *config.CommonConfig
+}
+
+// RestoreSnapshot rolls the source volume back to the snapshot, or creates a
+// new volume from it. Rolling back requires the source volume to be detached.
+// It returns the ID of the restored volume.
+func RestoreSnapshot(args SnapshotRestoreArgs) (string, error) {
+	clients, err := getServiceClients(args.CommonConfig)
+	if err != nil {
+		return "", err
+	}
+
+	snapshot, err := findSnapshot(clients, args.Snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	if args.NewVolume != "" {
+		volume, err := Create(CreateArgs{
+			Name:         args.NewVolume,
+			SnapshotID:   snapshot.ID,
+			Wait:         args.Wait,
+			Timeout:      args.Timeout,
+			CommonConfig: args.CommonConfig,
+		})
+		if err != nil {
+			return "", err
+		}
+		return volume.ID, nil
+	}
+
+	volume, err := findVolume(clients.blockStorage, snapshot.VolumeID)
+	if err != nil {
+		return "", err
+	}
+	if volume.Status != StatusAvailable {
+		return "", fmt.Errorf("volume %s is %s, detach it before rolling back", volume.Name, volume.Status)
+	}
+
+	if err := rollbackSnapshot(clients, snapshot.ID, volume.ID); err != nil {
+		return "", fmt.Errorf("failed to roll back volume %s to snapshot %s: %w", volume.Name, snapshot.Name, err)
+	}
+
+	if args.Wait {
+		if err := waitForStatus(clients.blockStorage, volume.ID, StatusAvailable, int(args.Timeout.Seconds())); err != nil {
+			return "", err
+		}
+	}
+	return volume.ID, nil
+}
+
+// rollbackSnapshot calls the OTC specific snapshot rollback API, which is
+// only offered in version 2 of the block storage API.
+func rollbackSnapshot(clients *serviceClients, snapshotID, volumeID string) error {
+	blockStorageV2, err := openstack.NewBlockStorageV2(clients.provider, clients.endpointOpts)
+	if err != nil {
+		return fmt.Errorf("failed to create EVS v2 client: %w", err)
+	}
+
+	body := map[string]interface{}{
+		"rollback": map[string]string{
+			"volume_id": volumeID,
+		},
+	}
+
+	_, err = blockStorageV2.Post(blockStorageV2.ServiceURL("os-vendor-snapshots", snapshotID, "rollback"), body, nil, &golangsdk.RequestOpts{
+		OkCodes: []int{200, 201, 202},
+	})
+	return err
+}
+
+// findSnapshot looks up a snapshot by ID or exact name, which has to be unique.
+func findSnapshot(clients *serviceClients, nameOrID string) (*snapshots.Snapshot, error) {
+	snapshotList, err := listSnapshots(clients, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []snapshots.Snapshot
+	for _, snapshot := range snapshotList {
+		if snapshot.ID == nameOrID {
+			return &snapshot, nil
+		}
+		if snapshot.Name == nameOrID {
+			matches = append(matches, snapshot)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &errs.NotFoundError{Resource: "snapshot", Name: nameOrID}
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &errs.AmbiguousError{Resource: "snapshot", Name: nameOrID, Count: len(matches), Hint: "use the snapshot ID"}
+	}
+}
+
+func hasMetadata(metadata, wanted map[string]string) bool {
+	for k, v := range wanted {
+		if actual, ok := metadata[k]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
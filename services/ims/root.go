@@ -0,0 +1,63 @@
+package ims
+
+import (
+	"fmt"
+	"otc-cli/client"
+	"otc-cli/config"
+	"sort"
+
+	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/ims/v2/images"
+)
+
+func getImsClient(commonConfig *config.CommonConfig) (*golangsdk.ServiceClient, error) {
+	opts, err := client.GetAuthOpts(commonConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := client.GetAuthenticatedClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate client: %w", err)
+	}
+
+	return openstack.NewIMSV2(c, golangsdk.EndpointOpts{
+		Region: commonConfig.Region,
+	})
+}
+
+type ListArgs struct {
+	Name string
+	// Visibility is one of public, private or shared; empty lists all.
+	Visibility string
+	// OsType is Linux or Windows; empty lists all.
+	OsType       string
+	Limit        int
+	CommonConfig *config.CommonConfig
+}
+
+// List returns the active images usable for ECS servers, ordered by name.
+func List(args ListArgs) ([]images.ImageInfo, error) {
+	ims, err := getImsClient(args.CommonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	imageList, err := images.ListImages(ims, images.ListImagesOpts{
+		Name:           args.Name,
+		Visibility:     args.Visibility,
+		OsType:         args.OsType,
+		Limit:          args.Limit,
+		Status:         "active",
+		VirtualEnvType: "FusionCompute",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	sort.Slice(imageList, func(i, j int) bool {
+		return imageList[i].Name < imageList[j].Name
+	})
+	return imageList, nil
+}
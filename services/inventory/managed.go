@@ -0,0 +1,65 @@
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// blockMarkers returns the comment lines enclosing the generated content.
+// The name distinguishes several managed blocks in one file, e.g. per cloud.
+func blockMarkers(name string) (begin, end string) {
+	return fmt.Sprintf("# BEGIN otc-cli managed block: %s", name),
+		fmt.Sprintf("# END otc-cli managed block: %s", name)
+}
+
+// WriteManagedBlock replaces the block with the given name in the file by
+// content, keeping everything outside of it. The block is appended when the
+// file does not contain it yet, and the file is created when missing.
+func WriteManagedBlock(path, name, content string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated, err := replaceManagedBlock(string(existing), name, content)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func replaceManagedBlock(existing, name, content string) (string, error) {
+	begin, end := blockMarkers(name)
+	block := begin + "\n" + strings.TrimRight(content, "\n") + "\n" + end + "\n"
+
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+
+	start := strings.Index(existing, begin+"\n")
+	if start < 0 {
+		if existing != "" {
+			existing += "\n"
+		}
+		return existing + block, nil
+	}
+
+	stop := strings.Index(existing[start:], end+"\n")
+	if stop < 0 {
+		return "", fmt.Errorf("managed block '%s' is not terminated by '%s'", name, end)
+	}
+	stop += start + len(end) + 1
+
+	return existing[:start] + block + existing[stop:], nil
+}
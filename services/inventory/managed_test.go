@@ -0,0 +1,100 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplaceManagedBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "empty file",
+			want: "# BEGIN otc-cli managed block: prod\nHost web\n# END otc-cli managed block: prod\n",
+		},
+		{
+			name:     "no block yet",
+			existing: "Host github.com\n    User git",
+			want: "Host github.com\n    User git\n\n" +
+				"# BEGIN otc-cli managed block: prod\nHost web\n# END otc-cli managed block: prod\n",
+		},
+		{
+			name: "existing block",
+			existing: "Host a\n\n" +
+				"# BEGIN otc-cli managed block: prod\nHost old\n# END otc-cli managed block: prod\n" +
+				"Host b\n",
+			want: "Host a\n\n" +
+				"# BEGIN otc-cli managed block: prod\nHost web\n# END otc-cli managed block: prod\n" +
+				"Host b\n",
+		},
+		{
+			name: "block of another cloud with a longer name",
+			existing: "# BEGIN otc-cli managed block: prod-eu\nHost eu\n# END otc-cli managed block: prod-eu\n" +
+				"# BEGIN otc-cli managed block: prod\nHost old\n# END otc-cli managed block: prod\n",
+			want: "# BEGIN otc-cli managed block: prod-eu\nHost eu\n# END otc-cli managed block: prod-eu\n" +
+				"# BEGIN otc-cli managed block: prod\nHost web\n# END otc-cli managed block: prod\n",
+		},
+		{
+			name:     "missing end marker",
+			existing: "# BEGIN otc-cli managed block: prod\nHost old\n",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replaceManagedBlock(tt.existing, "prod", "Host web\n\n")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("replaceManagedBlock() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("replaceManagedBlock() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteManagedBlockIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ssh", "config")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("Host github.com\n    User git\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var outputs []string
+	for i := 0; i < 2; i++ {
+		if err := WriteManagedBlock(path, "prod", "Host web\n    HostName 10.0.0.1\n"); err != nil {
+			t.Fatalf("WriteManagedBlock: %v", err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		outputs = append(outputs, string(data))
+	}
+
+	if outputs[0] != outputs[1] {
+		t.Errorf("second run changed the file:\n%s\nto\n%s", outputs[0], outputs[1])
+	}
+	if !strings.HasPrefix(outputs[1], "Host github.com\n    User git\n") {
+		t.Errorf("content outside of the block was not kept:\n%s", outputs[1])
+	}
+}
+
+func TestWriteManagedBlockCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory", "hosts.ini")
+	if err := WriteManagedBlock(path, "prod", "web ansible_host=10.0.0.1\n"); err != nil {
+		t.Fatalf("WriteManagedBlock: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("file was not created: %v", err)
+	}
+}
@@ -0,0 +1,178 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"otc-cli/services/ecs"
+	"otc-cli/services/ssh"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	FormatSSHConfig   = "ssh-config"
+	FormatAnsibleINI  = "ansible-ini"
+	FormatAnsibleYAML = "ansible-yaml"
+	FormatJSON        = "json"
+)
+
+var Formats = []string{FormatSSHConfig, FormatAnsibleINI, FormatAnsibleYAML, FormatJSON}
+
+// Options are the settings shared by all hosts of an inventory.
+type Options struct {
+	// JumpHost is written as ProxyJump into the SSH config.
+	JumpHost string
+	// KeyDir is where the private keys named after the key pairs are
+	// expected; used for IdentityFile entries of the keys found there.
+	KeyDir string
+}
+
+// Render writes the hosts in the given format.
+func Render(w io.Writer, format string, hosts []ecs.InventoryHost, opts Options) error {
+	switch format {
+	case FormatSSHConfig:
+		return renderSSHConfig(w, hosts, opts)
+	case FormatAnsibleINI:
+		return renderAnsibleINI(w, hosts, opts)
+	case FormatAnsibleYAML:
+		return renderAnsibleYAML(w, hosts, opts)
+	case FormatJSON:
+		data, err := json.MarshalIndent(hosts, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to marshal hosts: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	default:
+		return fmt.Errorf("unknown inventory format '%s', expected one of: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+func renderSSHConfig(w io.Writer, hosts []ecs.InventoryHost, opts Options) error {
+	for i, host := range hosts {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "Host %s\n", host.Name)
+		fmt.Fprintf(w, "    HostName %s\n", host.Address)
+		if host.User != "" {
+			fmt.Fprintf(w, "    User %s\n", host.User)
+		}
+		if keyFile := identityFile(host, opts); keyFile != "" {
+			fmt.Fprintf(w, "    IdentityFile %s\n", keyFile)
+		}
+		if opts.JumpHost != "" {
+			fmt.Fprintf(w, "    ProxyJump %s\n", opts.JumpHost)
+		}
+	}
+	return nil
+}
+
+func renderAnsibleINI(w io.Writer, hosts []ecs.InventoryHost, opts Options) error {
+	for _, host := range hosts {
+		vars := hostVars(host, opts)
+		keys := make([]string, 0, len(vars))
+		for k := range vars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		line := host.Name
+		for _, k := range keys {
+			value := vars[k]
+			if strings.ContainsAny(value, " \t") {
+				value = "'" + value + "'"
+			}
+			line += fmt.Sprintf(" %s=%s", k, value)
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	groups := groupMembers(hosts)
+	for _, group := range sortedKeys(groups) {
+		fmt.Fprintf(w, "\n[%s]\n", group)
+		for _, name := range groups[group] {
+			fmt.Fprintln(w, name)
+		}
+	}
+	return nil
+}
+
+func renderAnsibleYAML(w io.Writer, hosts []ecs.InventoryHost, opts Options) error {
+	type group struct {
+		Hosts    map[string]interface{} `yaml:"hosts,omitempty"`
+		Children map[string]group       `yaml:"children,omitempty"`
+	}
+
+	all := group{Hosts: map[string]interface{}{}, Children: map[string]group{}}
+	for _, host := range hosts {
+		all.Hosts[host.Name] = hostVars(host, opts)
+	}
+	for name, members := range groupMembers(hosts) {
+		child := group{Hosts: map[string]interface{}{}}
+		for _, member := range members {
+			child.Hosts[member] = nil
+		}
+		all.Children[name] = child
+	}
+
+	data, err := yaml.Marshal(map[string]group{"all": all})
+	if err != nil {
+		return fmt.Errorf("unable to marshal inventory to YAML: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func hostVars(host ecs.InventoryHost, opts Options) map[string]string {
+	vars := map[string]string{"ansible_host": host.Address}
+	if host.User != "" {
+		vars["ansible_user"] = host.User
+	}
+	if keyFile := identityFile(host, opts); keyFile != "" {
+		vars["ansible_ssh_private_key_file"] = keyFile
+	}
+	if opts.JumpHost != "" {
+		vars["ansible_ssh_common_args"] = "-J " + opts.JumpHost
+	}
+	return vars
+}
+
+// identityFile returns the private key of the host's key pair like ssh
+// looks it up, with or without a .pem extension. The path keeps the key
+// directory as given, e.g. with a leading ~.
+func identityFile(host ecs.InventoryHost, opts Options) string {
+	if opts.KeyDir == "" || host.KeyName == "" {
+		return ""
+	}
+
+	keyFile, err := ssh.KeyFile(opts.KeyDir, host.KeyName)
+	if err != nil || keyFile == "" {
+		return ""
+	}
+	return strings.TrimSuffix(opts.KeyDir, "/") + "/" + filepath.Base(keyFile)
+}
+
+func groupMembers(hosts []ecs.InventoryHost) map[string][]string {
+	groups := map[string][]string{}
+	for _, host := range hosts {
+		for _, group := range host.Groups {
+			groups[group] = append(groups[group], host.Name)
+		}
+	}
+	return groups
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
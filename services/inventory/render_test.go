@@ -0,0 +1,31 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"otc-cli/services/ecs"
+)
+
+func TestIdentityFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"deploy", "aws.pem"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for keyName, want := range map[string]string{
+		"deploy":  dir + "/deploy",
+		"aws":     dir + "/aws.pem",
+		"missing": "",
+	} {
+		if got := identityFile(ecs.InventoryHost{KeyName: keyName}, Options{KeyDir: dir + "/"}); got != want {
+			t.Errorf("identityFile(%q) = %q, want %q", keyName, got, want)
+		}
+	}
+	if got := identityFile(ecs.InventoryHost{KeyName: "deploy"}, Options{}); got != "" {
+		t.Errorf("identityFile without key directory = %q, want none", got)
+	}
+}
@@ -4,12 +4,19 @@ import (
 	"fmt"
 	"otc-cli/client"
 	"otc-cli/config"
+	"otc-cli/errs"
+	"slices"
+	"time"
 
 	golangsdk "github.com/opentelekomcloud/gophertelekomcloud"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack"
 	"github.com/opentelekomcloud/gophertelekomcloud/openstack/rds/v3/instances"
 )
 
+// createdLayout is the format of the creation time in RDS responses, e.g.
+// "2024-05-14T08:07:25+0000".
+const createdLayout = "2006-01-02T15:04:05-0700"
+
 func getRdsClient(commonConfig *config.CommonConfig) (*golangsdk.ServiceClient, error) {
 	opts, err := client.GetAuthOpts(commonConfig)
 	if err != nil {
@@ -18,7 +25,7 @@ func getRdsClient(commonConfig *config.CommonConfig) (*golangsdk.ServiceClient,
 
 	c, err := client.GetAuthenticatedClient(opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate client: %s", err)
+		return nil, fmt.Errorf("failed to authenticate client: %w", err)
 	}
 
 	return openstack.NewRDSV3(c, golangsdk.EndpointOpts{
@@ -44,3 +51,65 @@ func List(args *ListArgs) ([]instances.InstanceResponse, error) {
 
 	return response.Instances, nil
 }
+
+// Show returns the instance with the given name or ID.
+func Show(nameOrID string, commonConfig *config.CommonConfig) (*instances.InstanceResponse, error) {
+	rds, err := getRdsClient(commonConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	response, err := instances.List(rds, instances.ListOpts{Id: nameOrID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+	if len(response.Instances) == 1 {
+		return &response.Instances[0], nil
+	}
+
+	response, err = instances.List(rds, instances.ListOpts{Name: nameOrID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database: %w", err)
+	}
+
+	switch len(response.Instances) {
+	case 0:
+		return nil, &errs.NotFoundError{Resource: "instance", Name: nameOrID}
+	case 1:
+		return &response.Instances[0], nil
+	default:
+		return nil, &errs.AmbiguousError{Resource: "instance", Name: nameOrID, Count: len(response.Instances), Hint: "use the instance ID"}
+	}
+}
+
+// Created returns the creation time of the instance, or the zero time while
+// it is being created.
+func Created(i instances.InstanceResponse) time.Time {
+	for _, layout := range []string{createdLayout, time.RFC3339} {
+		if created, err := time.Parse(layout, i.Created); err == nil {
+			return created
+		}
+	}
+	return time.Time{}
+}
+
+// AvailabilityZones returns the distinct availability zones of the
+// instance's nodes.
+func AvailabilityZones(i instances.InstanceResponse) []string {
+	var zones []string
+	for _, n := range i.Nodes {
+		if n.AvailabilityZone != "" && !slices.Contains(zones, n.AvailabilityZone) {
+			zones = append(zones, n.AvailabilityZone)
+		}
+	}
+	return zones
+}
+
+// Tags returns the tags of the instance as a map.
+func Tags(i instances.InstanceResponse) map[string]string {
+	tags := make(map[string]string, len(i.Tags))
+	for _, t := range i.Tags {
+		tags[t.Key] = t.Value
+	}
+	return tags
+}
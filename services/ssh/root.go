@@ -0,0 +1,151 @@
+package ssh
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"otc-cli/config"
+	"otc-cli/errs"
+	"otc-cli/services/ecs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+)
+
+type Args struct {
+	Selector ecs.ServerSelector
+	User     string
+	// KeyDir is searched for a private key named after the server's key pair.
+	KeyDir   string
+	JumpHost string
+	// Address is the preferred address type, ecs.AddressFloating or ecs.AddressFixed.
+	// Without a preference, the floating address is used unless a jump host
+	// is configured.
+	Address string
+	// Command is appended to the ssh arguments, running it on the server.
+	Command      []string
+	CommonConfig *config.CommonConfig
+}
+
+// Connect resolves the server and runs the system ssh attached to the
+// terminal. A non-zero exit status of ssh, e.g. of the remote command, is
+// returned as *errs.ExitStatusError.
+func Connect(args Args) error {
+	server, err := ecs.Find(args.Selector, args.CommonConfig)
+	if err != nil {
+		return err
+	}
+
+	keyFile, err := KeyFile(args.KeyDir, server.KeyName)
+	if err != nil {
+		return err
+	}
+
+	sshArgs, err := BuildArgs(*server, args, keyFile)
+	if err != nil {
+		return err
+	}
+
+	// Interrupts are meant for ssh and the remote command, which receive
+	// them as well; this process waits for ssh to exit instead.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	defer signal.Stop(signals)
+
+	cmd := exec.Command("ssh", sshArgs...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return &errs.ExitStatusError{Command: "ssh", Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run ssh: %w", err)
+	}
+	return nil
+}
+
+// BuildArgs assembles the ssh command line arguments for a server.
+func BuildArgs(server servers.Server, args Args, keyFile string) ([]string, error) {
+	host, err := selectAddress(server, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var sshArgs []string
+	if keyFile != "" {
+		sshArgs = append(sshArgs, "-i", keyFile)
+	}
+	if args.JumpHost != "" {
+		sshArgs = append(sshArgs, "-J", args.JumpHost)
+	}
+
+	if args.User != "" {
+		host = args.User + "@" + host
+	}
+	sshArgs = append(sshArgs, host)
+
+	return append(sshArgs, args.Command...), nil
+}
+
+func selectAddress(server servers.Server, args Args) (string, error) {
+	preferred := args.Address
+	if preferred == "" {
+		preferred = ecs.AddressFloating
+		if args.JumpHost != "" {
+			preferred = ecs.AddressFixed
+		}
+	}
+
+	return ecs.PreferredAddress(server, preferred)
+}
+
+// KeyFile returns the private key for the key pair in the key directory
+// (~/.ssh when empty), or an empty string when there is none.
+func KeyFile(keyDir, keyName string) (string, error) {
+	keyDir, err := expandKeyDir(keyDir)
+	if err != nil {
+		return "", err
+	}
+	return findKeyFile(keyDir, keyName), nil
+}
+
+// expandKeyDir defaults the key directory to ~/.ssh and expands a leading ~.
+func expandKeyDir(keyDir string) (string, error) {
+	if keyDir != "" && keyDir != "~" && !strings.HasPrefix(keyDir, "~/") {
+		return keyDir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	if keyDir == "" {
+		return filepath.Join(home, ".ssh"), nil
+	}
+	return filepath.Join(home, strings.TrimPrefix(keyDir, "~")), nil
+}
+
+// findKeyFile returns the private key for the key pair, trying the key pair
+// name with and without a .pem extension. It returns an empty string when no
+// key is found, leaving the choice to ssh.
+func findKeyFile(keyDir, keyName string) string {
+	if keyName == "" {
+		return ""
+	}
+
+	for _, name := range []string{keyName, keyName + ".pem"} {
+		path := filepath.Join(keyDir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
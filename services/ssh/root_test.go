@@ -0,0 +1,122 @@
+package ssh
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"otc-cli/services/ecs"
+
+	"github.com/opentelekomcloud/gophertelekomcloud/openstack/compute/v2/servers"
+)
+
+// testServer returns a server with a fixed and a floating address.
+func testServer() servers.Server {
+	return servers.Server{
+		Name:    "web-01",
+		KeyName: "deploy",
+		Addresses: map[string]interface{}{
+			"subnet-web": []interface{}{
+				map[string]interface{}{"addr": "fe80::1", "version": float64(6), "OS-EXT-IPS:type": "fixed"},
+				map[string]interface{}{"addr": "192.168.0.10", "version": float64(4), "OS-EXT-IPS:type": "fixed"},
+				map[string]interface{}{"addr": "80.158.1.1", "version": float64(4), "OS-EXT-IPS:type": "floating"},
+			},
+		},
+	}
+}
+
+func TestSelectAddress(t *testing.T) {
+	fixedOnly := testServer()
+	fixedOnly.Addresses = map[string]interface{}{
+		"subnet-web": []interface{}{
+			map[string]interface{}{"addr": "192.168.0.10", "version": float64(4), "OS-EXT-IPS:type": "fixed"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		server  servers.Server
+		args    Args
+		want    string
+		wantErr bool
+	}{
+		{name: "floating by default", server: testServer(), want: "80.158.1.1"},
+		{name: "fixed behind a jump host", server: testServer(), args: Args{JumpHost: "bastion"}, want: "192.168.0.10"},
+		{name: "explicit preference wins", server: testServer(), args: Args{JumpHost: "bastion", Address: ecs.AddressFloating}, want: "80.158.1.1"},
+		{name: "fall back to any address", server: fixedOnly, want: "192.168.0.10"},
+		{name: "unknown address type", server: testServer(), args: Args{Address: "public"}, wantErr: true},
+		{name: "no address", server: servers.Server{Name: "building"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectAddress(tt.server, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectAddress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("selectAddress() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    Args
+		keyFile string
+		want    []string
+	}{
+		{name: "host only", want: []string{"80.158.1.1"}},
+		{
+			name:    "key, user and command",
+			args:    Args{User: "ubuntu", Command: []string{"uptime", "-p"}},
+			keyFile: "/home/user/.ssh/deploy.pem",
+			want:    []string{"-i", "/home/user/.ssh/deploy.pem", "ubuntu@80.158.1.1", "uptime", "-p"},
+		},
+		{
+			name: "jump host",
+			args: Args{User: "ubuntu", JumpHost: "admin@bastion"},
+			want: []string{"-J", "admin@bastion", "ubuntu@192.168.0.10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildArgs(testServer(), tt.args, tt.keyFile)
+			if err != nil {
+				t.Fatalf("BuildArgs: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("BuildArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"plain", "aws.pem", "both", "both.pem"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "directory"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	for keyName, want := range map[string]string{
+		"plain":     filepath.Join(dir, "plain"),
+		"aws":       filepath.Join(dir, "aws.pem"),
+		"both":      filepath.Join(dir, "both"),
+		"missing":   "",
+		"directory": "",
+		"":          "",
+	} {
+		if got := findKeyFile(dir, keyName); got != want {
+			t.Errorf("findKeyFile(%q) = %q, want %q", keyName, got, want)
+		}
+	}
+}